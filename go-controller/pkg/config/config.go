@@ -0,0 +1,68 @@
+package config
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+// GatewayConfig holds ovnkube-node's gateway-related flags.
+type GatewayConfig struct {
+	// Interface is the node's uplink interface used for gateway traffic,
+	// and for a VLAN-backed management port, the host uplink the tagged
+	// ovn-k8s-mp0 sub-interface rides on.
+	Interface string
+	// EnableMPTProxy turns on the TPROXY-based redirect of
+	// internalTrafficPolicy: Local service traffic to the management port,
+	// so it can be served without a round-trip through OVN.
+	EnableMPTProxy bool
+}
+
+// Gateway holds the parsed gateway flags.
+var Gateway GatewayConfig
+
+// GatewayFlags are the command-line flags that populate Gateway.
+var GatewayFlags = []cli.Flag{
+	&cli.BoolFlag{
+		Name:        "enable-mp-tproxy",
+		Usage:       "Redirect internalTrafficPolicy: Local service traffic to the management port via TPROXY instead of through OVN",
+		Destination: &Gateway.EnableMPTProxy,
+	},
+}
+
+// Flags is the full set of ovnkube-node command-line flags.
+var Flags = append([]cli.Flag{}, GatewayFlags...)
+
+// OvnKubeNodeConfig holds ovnkube-node's per-node management port flags.
+type OvnKubeNodeConfig struct {
+	// Mode is the ovnkube-node operating mode: full node, dpu or dpu-host.
+	Mode string
+	// MgmtPortNetdev is the host netdev handed off for a DPU host's
+	// management port.
+	MgmtPortNetdev string
+	// MgmtPortNetworkType selects how the host side of the management port
+	// is realized: "" (default, an OVS internal port) or "vlan" for a
+	// tagged sub-interface off Gateway.Interface.
+	MgmtPortNetworkType string
+	// MgmtPortVlanID is the VLAN tag used when MgmtPortNetworkType is "vlan".
+	MgmtPortVlanID int
+}
+
+// OvnKubeNode holds the parsed ovnkube-node management port flags.
+var OvnKubeNode OvnKubeNodeConfig
+
+// OvnKubeNodeFlags are the command-line flags that populate OvnKubeNode.
+var OvnKubeNodeFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "mgmt-port-network-type",
+		Usage:       "The network type used for the host side of the management port: \"\" for an OVS internal port, or \"vlan\" for a tagged sub-interface off --gateway-interface",
+		Destination: &OvnKubeNode.MgmtPortNetworkType,
+	},
+	&cli.IntFlag{
+		Name:        "mgmt-port-vlan-id",
+		Usage:       "The VLAN ID tagged onto the management port's host-side sub-interface when --mgmt-port-network-type=vlan",
+		Destination: &OvnKubeNode.MgmtPortVlanID,
+	},
+}
+
+func init() {
+	Flags = append(Flags, OvnKubeNodeFlags...)
+}
@@ -0,0 +1,929 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// tproxyChain is the mangle chain holding the TPROXY rules that
+	// redirect traffic for internalTrafficPolicy: Local services (and
+	// host-local-preferred pods) to a listener on the management port.
+	tproxyChain = "OVN-KUBE-TPROXY"
+	// tproxyMark/tproxyTable implement the policy routing leg of TPROXY:
+	// marked packets are handed to the local stack via table 100 instead
+	// of being routed normally.
+	tproxyMark  = "0x1/0x1"
+	tproxyTable = 100
+
+	// snatMgmtPortChain is the nat chain masquerading traffic leaving the
+	// default network's management port. Secondary networks get their own
+	// chain, suffixed with the network name; see managementPortGeneric.snatChainName.
+	snatMgmtPortChain = "OVN-KUBE-SNAT-MGMTPORT"
+)
+
+// postWaitFunc is returned by managementPort.Create and is invoked once the
+// node annotations it produced have been successfully applied.
+type postWaitFunc func() error
+
+// managementPort is what NewManagementPort hands back. Its one
+// implementation, managementPortGeneric, covers every ovnkube-node mode
+// (full node, DPU, DPU-host) by dispatching on the mode inside Create.
+type managementPort interface {
+	Create(nodeAnnotator kube.Annotator, waiter *startupWaiter) (postWaitFunc, error)
+	GatewayConfig() *GatewayConfig
+}
+
+// GatewayConfig is the addressing state of the management port, gathered
+// into one place so that other node subsystems (gateway_init, egress
+// services) can consume it instead of re-deriving it from the node's
+// subnets. It is populated atomically: either every configured family is
+// present, or Create failed and none of them are.
+type GatewayConfig struct {
+	// IPs holds the management port's own addresses, one per configured family.
+	IPs []net.IP
+	// MACs holds the LRP's neighbor MAC address, keyed by family.
+	MACs map[int]net.HardwareAddr
+	// Routes holds the routes to the cluster subnets via the LRP, keyed by family.
+	Routes map[int][]*netlink.Route
+}
+
+// managementPortConfig bundles together the addressing information needed
+// to program one IP family's worth of mgmt port state (address, gateway,
+// SNAT chain and LRP neighbor entry).
+type managementPortConfig struct {
+	family   int
+	protocol iptables.Protocol
+
+	ifName    string
+	snatChain string
+
+	// hostSubnet is the node's own, directly-connected subnet; it is only
+	// used to derive the mgmt port's address and the LRP's gateway IP.
+	// clusterCIDR is the cluster-wide subnet this host subnet was carved
+	// out of, and is what the mgmt port actually needs a route to via the
+	// LRP.
+	hostSubnet  *net.IPNet
+	clusterCIDR *net.IPNet
+	gatewayIP   net.IP
+	ifAddr      *net.IPNet
+}
+
+// NetInfo is the network identity NewManagementPortForNetwork needs to
+// target the right management port: the default cluster network, or a
+// secondary OVN network defined by a NetworkAttachmentDefinition.
+type NetInfo interface {
+	// GetNetworkName returns the network's name (the default network's is
+	// types.DefaultNetworkName).
+	GetNetworkName() string
+	// IsSecondary is false for the default cluster network and true for any
+	// network provisioned from a NetworkAttachmentDefinition.
+	IsSecondary() bool
+	// ClusterSubnets returns the cluster-wide pod subnets this network's host
+	// subnets are carved out of. For the default network these are
+	// config.Default.ClusterSubnets; a secondary network carries its own,
+	// taken from its NetworkAttachmentDefinition, so its routes never depend
+	// on that network's CIDR also being listed in --cluster-subnets.
+	ClusterSubnets() []*net.IPNet
+}
+
+// defaultNetInfo is the NetInfo of the default cluster network.
+type defaultNetInfo struct{}
+
+func (defaultNetInfo) GetNetworkName() string { return types.DefaultNetworkName }
+func (defaultNetInfo) IsSecondary() bool      { return false }
+
+func (defaultNetInfo) ClusterSubnets() []*net.IPNet {
+	subnets := make([]*net.IPNet, 0, len(config.Default.ClusterSubnets))
+	for _, entry := range config.Default.ClusterSubnets {
+		subnets = append(subnets, entry.CIDR)
+	}
+	return subnets
+}
+
+// managementPortGeneric is the mgmt port for every ovnkube-node mode: in
+// full node mode an OVS internal port plugged into br-int, on a DPU or
+// DPU-host the renamed representor netdev (see createPlatformManagementPort),
+// with IPAM and SNAT done by ovnkube-node itself except on a DPU. It is
+// used for the default cluster network's ovn-k8s-mp0 as well as, one
+// instance per network, the dedicated ovn-k8s-mp-<netName> ports secondary
+// OVN networks get.
+type managementPortGeneric struct {
+	netInfo     NetInfo
+	nodeName    string
+	hostSubnets []*net.IPNet
+
+	gwCfg *GatewayConfig
+}
+
+// NewManagementPort creates a new managementPort that, depending on the
+// configured ovnkube-node mode, knows how to create and program the
+// management port for the default cluster network (`ovn-k8s-mp0`) or hand
+// the job off to the appropriate DPU/DPU-host implementation.
+func NewManagementPort(nodeName string, hostSubnets []*net.IPNet) managementPort {
+	return NewManagementPortForNetwork(defaultNetInfo{}, nodeName, hostSubnets)
+}
+
+// NewManagementPortForNetwork creates a new managementPort for the given OVN
+// network. For the default cluster network this is the well-known
+// ovn-k8s-mp0; for a secondary network (one backed by a
+// NetworkAttachmentDefinition) it is a dedicated ovn-k8s-mp-<netName> port
+// with its own SNAT chain and route set, so secondary networks don't share
+// mgmt port state with the default network or with each other.
+func NewManagementPortForNetwork(netInfo NetInfo, nodeName string, hostSubnets []*net.IPNet) managementPort {
+	return &managementPortGeneric{
+		netInfo:     netInfo,
+		nodeName:    nodeName,
+		hostSubnets: hostSubnets,
+	}
+}
+
+// ifName returns the name of the OVS/netdev side of the management port:
+// ovn-k8s-mp0 for the default network, ovn-k8s-mp-<netName> for a secondary
+// network.
+func (mp *managementPortGeneric) ifName() string {
+	if mp.netInfo.IsSecondary() {
+		return types.ManagementPortPrefix + mp.netInfo.GetNetworkName()
+	}
+	return types.K8sMgmtIntfName
+}
+
+// snatChainName returns the name of the nat chain that masquerades traffic
+// leaving this network's management port: OVN-KUBE-SNAT-MGMTPORT for the
+// default network, OVN-KUBE-SNAT-MGMTPORT-<netName> for a secondary one, so
+// that networks never collide on each other's chain.
+func (mp *managementPortGeneric) snatChainName() string {
+	if mp.netInfo.IsSecondary() {
+		return snatMgmtPortChain + "-" + mp.netInfo.GetNetworkName()
+	}
+	return snatMgmtPortChain
+}
+
+// GatewayConfig returns the addressing state Create programmed onto the
+// management port, or nil if Create has not run (successfully) yet. It is
+// also nil on a DPU, where Create programs no addresses.
+func (mp *managementPortGeneric) GatewayConfig() *GatewayConfig {
+	return mp.gwCfg
+}
+
+// annotateManagementPortMAC records the management port's MAC address on the
+// node. The default network keeps using the existing single-value
+// annotation; secondary networks are recorded as entries of the
+// k8s.ovn.org/node-mgmt-port-mac-addresses JSON map, keyed by network name,
+// so multiple per-network ports can coexist on the same node.
+func (mp *managementPortGeneric) annotateManagementPortMAC(nodeAnnotator kube.Annotator, mac net.HardwareAddr) error {
+	if mp.netInfo.IsSecondary() {
+		return util.SetNodeManagementPortMACAddressForNetwork(nodeAnnotator, mp.netInfo.GetNetworkName(), mac)
+	}
+	return util.SetNodeManagementPortMACAddress(nodeAnnotator, mac)
+}
+
+// HasIPv4GlobalUnicastAddress returns true if the given addresses contain at
+// least one IPv4 global unicast address.
+func HasIPv4GlobalUnicastAddress(addrs []netlink.Addr) bool {
+	for _, addr := range addrs {
+		if utilnet.IsIPv4(addr.IP) && addr.IP.IsGlobalUnicast() {
+			return true
+		}
+	}
+	return false
+}
+
+// IPv6Only returns true if none of the node's addresses is an IPv4 global
+// unicast address, i.e. the node only has IPv6 connectivity. Hosts in this
+// state have no v4 stack to speak of, even when the cluster is configured
+// with a v4 clusterCIDR for a co-located v6-only pod network.
+func IPv6Only() (bool, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return false, fmt.Errorf("failed to list links: %v", err)
+	}
+
+	for _, link := range links {
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return false, fmt.Errorf("failed to list addresses for link %q: %v", link.Attrs().Name, err)
+		}
+		if HasIPv4GlobalUnicastAddress(addrs) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// clusterSubnetForHostSubnet returns, out of clusterSubnets, the one that
+// hostSubnet was carved out of, so callers can route to the cluster via the
+// LRP instead of to the node's own, directly-connected subnet.
+func clusterSubnetForHostSubnet(clusterSubnets []*net.IPNet, hostSubnet *net.IPNet) (*net.IPNet, error) {
+	for _, clusterSubnet := range clusterSubnets {
+		if clusterSubnet.Contains(hostSubnet.IP) {
+			return clusterSubnet, nil
+		}
+	}
+	return nil, fmt.Errorf("no configured cluster subnet contains host subnet %s", hostSubnet.String())
+}
+
+// newManagementPortConfigs builds one managementPortConfig per host subnet,
+// skipping any IPv4 subnet when the node is IPv6-only. clusterSubnets are the
+// network's own cluster-wide pod subnets (see NetInfo.ClusterSubnets), used
+// to resolve each host subnet's route to the cluster.
+func newManagementPortConfigs(ifName, snatChain string, hostSubnets []*net.IPNet, clusterSubnets []*net.IPNet) ([]*managementPortConfig, error) {
+	v6Only, err := IPv6Only()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if node is IPv6-only: %v", err)
+	}
+
+	var cfgs []*managementPortConfig
+	for _, hostSubnet := range hostSubnets {
+		isIPv4 := utilnet.IsIPv4CIDR(hostSubnet)
+		if isIPv4 && v6Only {
+			klog.Infof("Node has no IPv4 address; skipping IPv4 management port configuration for subnet %s", hostSubnet.String())
+			continue
+		}
+
+		family := netlink.FAMILY_V4
+		protocol := iptables.ProtocolIPv4
+		if !isIPv4 {
+			family = netlink.FAMILY_V6
+			protocol = iptables.ProtocolIPv6
+		}
+
+		clusterCIDR, err := clusterSubnetForHostSubnet(clusterSubnets, hostSubnet)
+		if err != nil {
+			return nil, err
+		}
+
+		mpIP := util.GetNodeManagementIfAddr(hostSubnet).IP
+		gwIP := util.GetNodeGatewayIfAddr(hostSubnet).IP
+
+		cfgs = append(cfgs, &managementPortConfig{
+			family:      family,
+			protocol:    protocol,
+			ifName:      ifName,
+			snatChain:   snatChain,
+			hostSubnet:  hostSubnet,
+			clusterCIDR: clusterCIDR,
+			gatewayIP:   gwIP,
+			ifAddr: &net.IPNet{
+				IP:   mpIP,
+				Mask: hostSubnet.Mask,
+			},
+		})
+	}
+	return cfgs, nil
+}
+
+// mgmtPortMTU is the MTU ovnkube-node requests for ovn-k8s-mp0; it must stay
+// below the cluster network's MTU to leave room for the Geneve encapsulation.
+const mgmtPortMTU = 1400
+
+// createPlatformManagementPort creates the OVS/netdev side of the
+// management port appropriate for the configured ovnkube-node mode and
+// returns the port's MAC address.
+func createPlatformManagementPort(nodeName, ifName string, hostSubnets []*net.IPNet, isDefaultNetwork bool) (net.HardwareAddr, error) {
+	switch config.OvnKubeNode.Mode {
+	case types.NodeModeDPU:
+		return createDPUManagementPort(nodeName, ifName)
+	case types.NodeModeDPUHost:
+		return createDPUHostManagementPort(ifName)
+	default:
+		return createFullNodeManagementPort(nodeName, ifName, isDefaultNetwork)
+	}
+}
+
+// createFullNodeManagementPort creates the OVS internal port used on a
+// regular (non-DPU) node, assigns it a MAC and makes sure br-int has the
+// passthrough OpenFlow rule mgmt port traffic needs. For the default
+// network's ovn-k8s-mp0 it also migrates away from the legacy "k8s-<node>"
+// OVS port name; secondary networks never had such a legacy name, so their
+// iface-id is just their own port name. VLAN mode is only ever applied to
+// the default network's uplink: isDefaultNetwork gates it the same way
+// Create gates snatIfName, so a secondary network never races the default
+// network for the same (uplink, VLAN ID) sub-interface.
+func createFullNodeManagementPort(nodeName, ifName string, isDefaultNetwork bool) (net.HardwareAddr, error) {
+	ifaceID := ifName
+	args := []string{}
+	if ifName == types.K8sMgmtIntfName {
+		ifaceID = types.K8sPrefix + nodeName
+		args = append(args, "--", "--if-exists", "del-port", "br-int", ifaceID)
+	}
+	args = append(args,
+		"--", "--may-exist", "add-port", "br-int", ifName,
+		"--", "set", "interface", ifName,
+		"type=internal", fmt.Sprintf("mtu_request=%d", mgmtPortMTU),
+		"external-ids:iface-id="+ifaceID,
+	)
+	_, stderr, err := util.RunOVSVsctl(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create management port %s: stderr=%q, err=%v", ifName, stderr, err)
+	}
+
+	macRaw, stderr, err := util.RunOVSVsctl("--if-exists", "get", "interface", ifName, "mac_in_use")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get management port MAC address: stderr=%q, err=%v", stderr, err)
+	}
+	mac, err := net.ParseMAC(strings.TrimSpace(macRaw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse management port MAC address %q: %v", macRaw, err)
+	}
+
+	if _, stderr, err := util.RunOVSVsctl("set", "interface", ifName, "mac="+escapeMAC(mac.String())); err != nil {
+		return nil, fmt.Errorf("failed to set management port MAC address: stderr=%q, err=%v", stderr, err)
+	}
+
+	if err := ensureManagementPortOpenFlow(ifName); err != nil {
+		return nil, err
+	}
+
+	if isDefaultNetwork && config.OvnKubeNode.MgmtPortNetworkType == types.NetworkTypeVLAN {
+		if err := configureManagementPortVLAN(ifName); err != nil {
+			return nil, err
+		}
+	}
+
+	return mac, nil
+}
+
+// configureManagementPortVLAN tags the OVS side of the management port with
+// the configured VLAN and creates the host-side VLAN sub-interface
+// (ovn-k8s-mp0.<vid>) off the configured uplink, so that VLAN/underlay
+// deployments can put node/pod traffic on a tagged host network instead of
+// the default Geneve overlay.
+func configureManagementPortVLAN(ifName string) error {
+	vlanID := config.OvnKubeNode.MgmtPortVlanID
+	if _, stderr, err := util.RunOVSVsctl("set", "port", ifName, fmt.Sprintf("tag=%d", vlanID)); err != nil {
+		return fmt.Errorf("failed to set VLAN tag %d on %s: stderr=%q, err=%v", vlanID, ifName, stderr, err)
+	}
+
+	uplink, err := netlink.LinkByName(config.Gateway.Interface)
+	if err != nil {
+		return fmt.Errorf("failed to look up management port VLAN uplink %s: %v", config.Gateway.Interface, err)
+	}
+
+	vlanIfName := vlanManagementPortIfName(ifName, vlanID)
+	vlanLink := &netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        vlanIfName,
+			ParentIndex: uplink.Attrs().Index,
+			MTU:         mgmtPortMTU,
+		},
+		VlanId: vlanID,
+	}
+	if err := netlink.LinkAdd(vlanLink); err != nil && err != unix.EEXIST {
+		return fmt.Errorf("failed to create VLAN sub-interface %s: %v", vlanIfName, err)
+	}
+
+	link, err := netlink.LinkByName(vlanIfName)
+	if err != nil {
+		return fmt.Errorf("failed to look up VLAN sub-interface %s: %v", vlanIfName, err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("failed to set %s up: %v", vlanIfName, err)
+	}
+
+	return nil
+}
+
+// vlanManagementPortIfName returns the name of the VLAN sub-interface that
+// carries the management port's traffic over the host uplink when
+// --mgmt-port-network-type=vlan is configured.
+func vlanManagementPortIfName(ifName string, vlanID int) string {
+	return fmt.Sprintf("%s.%d", ifName, vlanID)
+}
+
+// createDPUManagementPort plugs the host-facing representor netdev into
+// br-int under the well-known ovn-k8s-mp0 name, on the DPU itself.
+func createDPUManagementPort(nodeName, ifName string) (net.HardwareAddr, error) {
+	link, err := renameManagementPortNetdev(ifName)
+	if err != nil {
+		return nil, err
+	}
+
+	ifaceID := types.K8sPrefix + nodeName
+	if _, stderr, err := util.RunOVSVsctl(
+		"--", "--may-exist", "add-port", "br-int", ifName,
+		"--", "set", "interface", ifName, "external-ids:iface-id="+ifaceID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to add DPU management port %s: stderr=%q, err=%v", ifName, stderr, err)
+	}
+
+	if err := ensureManagementPortOpenFlow(ifName); err != nil {
+		return nil, err
+	}
+
+	if err := finalizeManagementPortNetdev(link, ifName); err != nil {
+		return nil, err
+	}
+
+	return link.Attrs().HardwareAddr, nil
+}
+
+// createDPUHostManagementPort renames the representor netdev on the DPU host
+// side; there is no local br-int here, OVN/OVS runs on the DPU itself.
+func createDPUHostManagementPort(ifName string) (net.HardwareAddr, error) {
+	link, err := renameManagementPortNetdev(ifName)
+	if err != nil {
+		return nil, err
+	}
+	if err := finalizeManagementPortNetdev(link, ifName); err != nil {
+		return nil, err
+	}
+	return link.Attrs().HardwareAddr, nil
+}
+
+// renameManagementPortNetdev renames the configured DPU mgmt port netdev to
+// ifName, bringing it down first since netlink requires that for a rename.
+func renameManagementPortNetdev(ifName string) (netlink.Link, error) {
+	netdevName := config.OvnKubeNode.MgmtPortNetdev
+	link, err := netlink.LinkByName(netdevName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up management port netdev %s: %v", netdevName, err)
+	}
+	if err := netlink.LinkSetDown(link); err != nil {
+		return nil, fmt.Errorf("failed to bring %s down for rename: %v", netdevName, err)
+	}
+	if err := netlink.LinkSetName(link, ifName); err != nil {
+		return nil, fmt.Errorf("failed to rename %s to %s: %v", netdevName, ifName, err)
+	}
+	link, err = netlink.LinkByName(ifName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up renamed management port %s: %v", ifName, err)
+	}
+	return link, nil
+}
+
+// finalizeManagementPortNetdev sets the MTU ovnkube-node expects and brings
+// the link back up once it has its final name.
+func finalizeManagementPortNetdev(link netlink.Link, ifName string) error {
+	if err := netlink.LinkSetMTU(link, mgmtPortMTU); err != nil {
+		return fmt.Errorf("failed to set MTU on %s: %v", ifName, err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		return fmt.Errorf("failed to set %s up: %v", ifName, err)
+	}
+	return nil
+}
+
+// ensureManagementPortOpenFlow makes sure br-int has the table=65 passthrough
+// flow for the mgmt port's ofport, adding it if it is missing.
+func ensureManagementPortOpenFlow(ifName string) error {
+	ofportRaw, stderr, err := util.RunOVSVsctl("--if-exists", "get", "interface", ifName, "ofport")
+	if err != nil {
+		return fmt.Errorf("failed to get ofport for %s: stderr=%q, err=%v", ifName, stderr, err)
+	}
+	ofport := strings.TrimSpace(ofportRaw)
+
+	flows, stderr, err := util.RunOVSOfctl("--no-stats", "--no-names", "dump-flows", "br-int", "table=65,out_port="+ofport)
+	if err != nil {
+		return fmt.Errorf("failed to dump flows for %s: stderr=%q, err=%v", ifName, stderr, err)
+	}
+	if strings.Contains(flows, "actions=output:"+ofport) {
+		return nil
+	}
+	if _, stderr, err := util.RunOVSOfctl("add-flow", "br-int",
+		fmt.Sprintf("table=65,priority=100,reg15=0x2,metadata=0x2,actions=output:%s", ofport)); err != nil {
+		return fmt.Errorf("failed to add passthrough flow for %s: stderr=%q, err=%v", ifName, stderr, err)
+	}
+	return nil
+}
+
+// Create sets up the management port for the node: the OVS internal port,
+// its IP addresses, routes to the cluster subnets, the LRP's neighbor entry
+// and the SNAT chain used to masquerade pod-to-host traffic. IPv4-specific
+// state is skipped entirely on IPv6-only nodes. Every configured family is
+// programmed in a single pass: if any family fails, that family and the
+// ones applied before it are rolled back so the mgmt port never ends up
+// half-configured.
+// On a DPU, IP-level configuration is the DPU-host's job; Create only wires
+// up the OVS side of the port.
+func (mp *managementPortGeneric) Create(nodeAnnotator kube.Annotator, waiter *startupWaiter) (postWaitFunc, error) {
+	ifName := mp.ifName()
+
+	// On a VLAN-backed management port, traffic actually leaves the host via
+	// the VLAN sub-interface of the uplink rather than ovn-k8s-mp0 itself, so
+	// the SNAT chain must match egress on that device instead. VLAN mode is
+	// only meaningful for the default network's uplink.
+	snatIfName := ifName
+	if !mp.netInfo.IsSecondary() && config.OvnKubeNode.MgmtPortNetworkType == types.NetworkTypeVLAN {
+		snatIfName = vlanManagementPortIfName(ifName, config.OvnKubeNode.MgmtPortVlanID)
+	}
+
+	cfgs, err := newManagementPortConfigs(snatIfName, mp.snatChainName(), mp.hostSubnets, mp.netInfo.ClusterSubnets())
+	if err != nil {
+		return nil, err
+	}
+
+	macAddress, err := createPlatformManagementPort(mp.nodeName, ifName, mp.hostSubnets, !mp.netInfo.IsSecondary())
+	if err != nil {
+		return nil, err
+	}
+
+	if config.OvnKubeNode.Mode == types.NodeModeDPU {
+		if nodeAnnotator != nil {
+			if err := mp.annotateManagementPortMAC(nodeAnnotator, macAddress); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	link, err := netlink.LinkByName(ifName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %s: %v", ifName, err)
+	}
+
+	// The logical router port backing the gateway has a single MAC shared by
+	// every family it serves; derive it once (preferring v4, as OVN does)
+	// rather than per-family, so all neighbor entries agree on it.
+	lrpMAC := lrpMACForConfigs(cfgs)
+
+	gwCfg := &GatewayConfig{
+		MACs:   map[int]net.HardwareAddr{},
+		Routes: map[int][]*netlink.Route{},
+	}
+
+	var applied []*managementPortConfig
+	for _, cfg := range cfgs {
+		// Track the family before its first step, so that a failure part-way
+		// through it is rolled back along with the families before it.
+		applied = append(applied, cfg)
+		route, err := configureManagementPortAddresses(link, cfg, lrpMAC)
+		if err != nil {
+			rollbackManagementPort(link, lrpMAC, mp.netInfo.IsSecondary(), applied)
+			return nil, err
+		}
+
+		if err := configureManagementPortSNAT(cfg); err != nil {
+			rollbackManagementPort(link, lrpMAC, mp.netInfo.IsSecondary(), applied)
+			return nil, err
+		}
+
+		// mp-tproxy redirects default-network ClusterIP traffic; secondary
+		// networks don't carry cluster services, so skip it for those.
+		if !mp.netInfo.IsSecondary() {
+			if err := configureManagementPortTProxy(cfg); err != nil {
+				rollbackManagementPort(link, lrpMAC, mp.netInfo.IsSecondary(), applied)
+				return nil, err
+			}
+		}
+
+		gwCfg.IPs = append(gwCfg.IPs, cfg.ifAddr.IP)
+		gwCfg.MACs[cfg.family] = lrpMAC
+		gwCfg.Routes[cfg.family] = append(gwCfg.Routes[cfg.family], route)
+	}
+	mp.gwCfg = gwCfg
+
+	if nodeAnnotator != nil {
+		if err := mp.annotateManagementPortMAC(nodeAnnotator, macAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// lrpMACForConfigs returns the single MAC address the gateway's logical
+// router port should use across every family it serves, preferring the v4
+// gateway IP when one is configured (matching how OVN derives the GR's own
+// Ethernet address).
+func lrpMACForConfigs(cfgs []*managementPortConfig) net.HardwareAddr {
+	for _, cfg := range cfgs {
+		if cfg.family == netlink.FAMILY_V4 {
+			return util.IPAddrToHWAddr(cfg.gatewayIP)
+		}
+	}
+	if len(cfgs) > 0 {
+		return util.IPAddrToHWAddr(cfgs[0].gatewayIP)
+	}
+	return nil
+}
+
+// configureManagementPortAddresses programs the mgmt port IP address, the
+// route to the cluster subnet via the LRP and the LRP's static neighbor
+// entry for a single IP family, and returns the route it installed.
+func configureManagementPortAddresses(link netlink.Link, cfg *managementPortConfig, lrpMAC net.HardwareAddr) (*netlink.Route, error) {
+	addr, err := netlink.ParseAddr(cfg.ifAddr.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse management port address %s: %v", cfg.ifAddr.String(), err)
+	}
+	if err := netlink.AddrReplace(link, addr); err != nil {
+		return nil, fmt.Errorf("failed to add management port address %s: %v", addr.String(), err)
+	}
+
+	route := &netlink.Route{
+		Dst:       cfg.clusterCIDR,
+		LinkIndex: link.Attrs().Index,
+		Gw:        cfg.gatewayIP,
+	}
+	if err := netlink.RouteReplace(route); err != nil {
+		return nil, fmt.Errorf("failed to add route to %s via %s: %v", cfg.clusterCIDR.String(), cfg.gatewayIP.String(), err)
+	}
+
+	neigh := &netlink.Neigh{
+		LinkIndex:    link.Attrs().Index,
+		Family:       cfg.family,
+		State:        netlink.NUD_PERMANENT,
+		IP:           cfg.gatewayIP,
+		HardwareAddr: lrpMAC,
+	}
+	if err := netlink.NeighSet(neigh); err != nil {
+		return nil, fmt.Errorf("failed to set neighbor entry for %s: %v", cfg.gatewayIP.String(), err)
+	}
+
+	return route, nil
+}
+
+// rollbackManagementPort undoes everything Create may have applied for every
+// family in cfgs: the address, route and neighbor entry configureManagement-
+// PortAddresses installs, plus the per-family SNAT chain and (for the
+// default network) the mp-tproxy chain and policy route. It is called on any
+// mid-loop failure so Create never leaves the mgmt port half-configured with
+// a mix of old and new families; every step is a best-effort teardown since
+// some of them may never have been reached for the family that just failed.
+func rollbackManagementPort(link netlink.Link, lrpMAC net.HardwareAddr, isSecondary bool, cfgs []*managementPortConfig) {
+	for _, cfg := range cfgs {
+		addr, err := netlink.ParseAddr(cfg.ifAddr.String())
+		if err != nil {
+			continue
+		}
+		if err := netlink.AddrDel(link, addr); err != nil {
+			klog.Warningf("Failed to roll back management port address %s: %v", addr.String(), err)
+		}
+		route := &netlink.Route{Dst: cfg.clusterCIDR, LinkIndex: link.Attrs().Index, Gw: cfg.gatewayIP}
+		if err := netlink.RouteDel(route); err != nil {
+			klog.Warningf("Failed to roll back route to %s: %v", cfg.clusterCIDR.String(), err)
+		}
+		neigh := &netlink.Neigh{
+			LinkIndex:    link.Attrs().Index,
+			Family:       cfg.family,
+			State:        netlink.NUD_PERMANENT,
+			IP:           cfg.gatewayIP,
+			HardwareAddr: lrpMAC,
+		}
+		if err := netlink.NeighDel(neigh); err != nil {
+			klog.Warningf("Failed to roll back neighbor entry for %s: %v", cfg.gatewayIP.String(), err)
+		}
+
+		rollbackManagementPortSNAT(cfg)
+
+		if !isSecondary {
+			if err := DisableManagementPortTProxy(cfg.protocol, cfg.family); err != nil {
+				klog.Warningf("Failed to roll back mp-tproxy state for %s: %v", cfg.ifName, err)
+			}
+		}
+	}
+}
+
+// configureManagementPortSNAT installs the chain that masquerades traffic
+// leaving the mgmt port as the mgmt port's own address, for a single IP
+// family. The chain is named after cfg.snatChain so that secondary networks
+// never collide with the default network's OVN-KUBE-SNAT-MGMTPORT chain or
+// with each other's.
+func configureManagementPortSNAT(cfg *managementPortConfig) error {
+	ipt, err := util.GetIPTablesHelper(cfg.protocol)
+	if err != nil {
+		return err
+	}
+
+	if err := ipt.NewChain("nat", cfg.snatChain); err != nil && !util.IsChainExistsError(err) {
+		return fmt.Errorf("failed to create %s chain: %v", cfg.snatChain, err)
+	}
+
+	rule := []string{"-o", cfg.ifName, "-j", cfg.snatChain}
+	exists, err := ipt.Exists("nat", "POSTROUTING", rule...)
+	if err != nil {
+		return fmt.Errorf("failed to check for POSTROUTING SNAT jump rule: %v", err)
+	}
+	if !exists {
+		if err := ipt.Insert("nat", "POSTROUTING", 1, rule...); err != nil {
+			return fmt.Errorf("failed to add POSTROUTING SNAT jump rule: %v", err)
+		}
+	}
+
+	snatRule := []string{
+		"-o", cfg.ifName,
+		"-j", "SNAT", "--to-source", cfg.ifAddr.IP.String(),
+		"-m", "comment", "--comment", "OVN SNAT to Management Port",
+	}
+	exists, err = ipt.Exists("nat", cfg.snatChain, snatRule...)
+	if err != nil {
+		return fmt.Errorf("failed to check for SNAT rule: %v", err)
+	}
+	if !exists {
+		if err := ipt.Insert("nat", cfg.snatChain, 1, snatRule...); err != nil {
+			return fmt.Errorf("failed to add SNAT rule: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// rollbackManagementPortSNAT undoes configureManagementPortSNAT for a single
+// IP family: the POSTROUTING jump rule and the SNAT chain itself, so a
+// rolled-back family leaves no orphaned nat rules behind. It is a best-effort
+// teardown - failures are logged rather than propagated, since the caller is
+// already unwinding a previous error.
+func rollbackManagementPortSNAT(cfg *managementPortConfig) {
+	ipt, err := util.GetIPTablesHelper(cfg.protocol)
+	if err != nil {
+		klog.Warningf("Failed to roll back SNAT chain %s: %v", cfg.snatChain, err)
+		return
+	}
+
+	rule := []string{"-o", cfg.ifName, "-j", cfg.snatChain}
+	if exists, err := ipt.Exists("nat", "POSTROUTING", rule...); err != nil {
+		klog.Warningf("Failed to check for POSTROUTING SNAT jump rule for %s: %v", cfg.snatChain, err)
+	} else if exists {
+		if err := ipt.Delete("nat", "POSTROUTING", rule...); err != nil {
+			klog.Warningf("Failed to roll back POSTROUTING SNAT jump rule for %s: %v", cfg.snatChain, err)
+		}
+	}
+
+	if err := ipt.ClearChain("nat", cfg.snatChain); err != nil && !util.IsChainNotExistError(err) {
+		klog.Warningf("Failed to clear SNAT chain %s: %v", cfg.snatChain, err)
+	}
+	if err := ipt.DeleteChain("nat", cfg.snatChain); err != nil && !util.IsChainNotExistError(err) {
+		klog.Warningf("Failed to delete SNAT chain %s: %v", cfg.snatChain, err)
+	}
+}
+
+// configureManagementPortTProxy installs the mangle/OVN-KUBE-TPROXY chain
+// and the policy routing it relies on, for a single IP family. It is a
+// no-op unless --enable-mp-tproxy was passed, in which case the kube-proxy-
+// less datapath can use AddLocalServiceTProxyRule to redirect individual
+// services into a listener on the management port instead of round-tripping
+// through OVN for local endpoints.
+func configureManagementPortTProxy(cfg *managementPortConfig) error {
+	if !config.Gateway.EnableMPTProxy {
+		return nil
+	}
+
+	ipt, err := util.GetIPTablesHelper(cfg.protocol)
+	if err != nil {
+		return err
+	}
+
+	if err := ipt.NewChain("mangle", tproxyChain); err != nil && !util.IsChainExistsError(err) {
+		return fmt.Errorf("failed to create %s chain: %v", tproxyChain, err)
+	}
+
+	jump := []string{"-j", tproxyChain}
+	exists, err := ipt.Exists("mangle", "PREROUTING", jump...)
+	if err != nil {
+		return fmt.Errorf("failed to check for PREROUTING TPROXY jump rule: %v", err)
+	}
+	if !exists {
+		if err := ipt.Insert("mangle", "PREROUTING", 1, jump...); err != nil {
+			return fmt.Errorf("failed to add PREROUTING TPROXY jump rule: %v", err)
+		}
+	}
+
+	return addTProxyPolicyRouting(cfg.family)
+}
+
+// addTProxyPolicyRouting adds the `ip rule add fwmark ... table 100` /
+// `ip route add local default dev lo table 100` pair that hands TPROXY-
+// marked packets to the local stack, for a single IP family.
+func addTProxyPolicyRouting(family int) error {
+	rule := netlink.NewRule()
+	rule.Mark = 1
+	rule.Mask = 1
+	rule.Table = tproxyTable
+	rule.Family = family
+	if err := netlink.RuleAdd(rule); err != nil && err != unix.EEXIST {
+		return fmt.Errorf("failed to add fwmark ip rule for TPROXY: %v", err)
+	}
+
+	lo, err := netlink.LinkByName("lo")
+	if err != nil {
+		return fmt.Errorf("failed to look up loopback interface: %v", err)
+	}
+	route := &netlink.Route{
+		LinkIndex: lo.Attrs().Index,
+		Scope:     netlink.SCOPE_HOST,
+		Table:     tproxyTable,
+		Type:      unix.RTN_LOCAL,
+		Family:    family,
+	}
+	if err := netlink.RouteReplace(route); err != nil {
+		return fmt.Errorf("failed to add local default route for TPROXY: %v", err)
+	}
+	return nil
+}
+
+// DisableManagementPortTProxy tears down everything configureManagementPortTProxy
+// installed for a single IP family: the PREROUTING jump, the OVN-KUBE-TPROXY
+// chain itself and the fwmark policy route. It is called when mp-tproxy is
+// turned off at runtime.
+func DisableManagementPortTProxy(protocol iptables.Protocol, family int) error {
+	ipt, err := util.GetIPTablesHelper(protocol)
+	if err != nil {
+		return err
+	}
+
+	jump := []string{"-j", tproxyChain}
+	var jumpErr error
+	if exists, err := ipt.Exists("mangle", "PREROUTING", jump...); err != nil {
+		// The chain itself is still removed below; the error is returned at the end.
+		jumpErr = fmt.Errorf("failed to check for PREROUTING TPROXY jump rule: %v", err)
+	} else if exists {
+		if err := ipt.Delete("mangle", "PREROUTING", jump...); err != nil {
+			return fmt.Errorf("failed to remove PREROUTING TPROXY jump rule: %v", err)
+		}
+	}
+	if err := ipt.ClearChain("mangle", tproxyChain); err != nil && !util.IsChainNotExistError(err) {
+		return fmt.Errorf("failed to clear %s chain: %v", tproxyChain, err)
+	}
+	if err := ipt.DeleteChain("mangle", tproxyChain); err != nil && !util.IsChainNotExistError(err) {
+		return fmt.Errorf("failed to delete %s chain: %v", tproxyChain, err)
+	}
+
+	rule := netlink.NewRule()
+	rule.Mark = 1
+	rule.Mask = 1
+	rule.Table = tproxyTable
+	rule.Family = family
+	if err := netlink.RuleDel(rule); err != nil && err != unix.ESRCH {
+		return fmt.Errorf("failed to remove fwmark ip rule for TPROXY: %v", err)
+	}
+
+	lo, err := netlink.LinkByName("lo")
+	if err != nil {
+		return fmt.Errorf("failed to look up loopback interface: %v", err)
+	}
+	route := &netlink.Route{LinkIndex: lo.Attrs().Index, Table: tproxyTable, Family: family}
+	if err := netlink.RouteDel(route); err != nil && err != unix.ESRCH {
+		return fmt.Errorf("failed to remove local default route for TPROXY: %v", err)
+	}
+
+	return jumpErr
+}
+
+// AddLocalServiceTProxyRule installs a mangle/OVN-KUBE-TPROXY rule that
+// redirects traffic destined for a ClusterIP service with
+// internalTrafficPolicy: Local (or a pod carrying the host-local-preferred
+// annotation) into a listening socket on the management port, so it can be
+// served locally without a round-trip through OVN.
+func AddLocalServiceTProxyRule(protocol iptables.Protocol, proto string, svcIP net.IP, svcPort int32, listenPort int32) error {
+	if !config.Gateway.EnableMPTProxy {
+		return nil
+	}
+	ipt, err := util.GetIPTablesHelper(protocol)
+	if err != nil {
+		return err
+	}
+	exists, err := ipt.Exists("mangle", tproxyChain, tproxyRule(proto, svcIP, svcPort, listenPort)...)
+	if err != nil {
+		return fmt.Errorf("failed to check for TPROXY rule: %v", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := ipt.Insert("mangle", tproxyChain, 1, tproxyRule(proto, svcIP, svcPort, listenPort)...); err != nil {
+		return fmt.Errorf("failed to add TPROXY rule: %v", err)
+	}
+	return nil
+}
+
+// DeleteLocalServiceTProxyRule removes a rule previously installed by
+// AddLocalServiceTProxyRule.
+func DeleteLocalServiceTProxyRule(protocol iptables.Protocol, proto string, svcIP net.IP, svcPort int32, listenPort int32) error {
+	ipt, err := util.GetIPTablesHelper(protocol)
+	if err != nil {
+		return err
+	}
+	return ipt.Delete("mangle", tproxyChain, tproxyRule(proto, svcIP, svcPort, listenPort)...)
+}
+
+func tproxyRule(proto string, svcIP net.IP, svcPort, listenPort int32) []string {
+	return []string{
+		"-p", proto,
+		"-d", svcIP.String(),
+		"--dport", fmt.Sprintf("%d", svcPort),
+		"-j", "TPROXY",
+		"--on-port", fmt.Sprintf("%d", listenPort),
+		"--tproxy-mark", tproxyMark,
+	}
+}
+
+// escapeMAC turns a MAC address into the form ovs-vsctl expects when it is
+// passed as a `mac=` column value (the colons need escaping).
+func escapeMAC(mac string) string {
+	return strings.ReplaceAll(mac, ":", "\\:")
+}
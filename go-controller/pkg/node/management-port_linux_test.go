@@ -76,42 +76,55 @@ func (mptc *managementPortTestConfig) GetMgtPortAddr() *netlink.Addr {
 	return mgtPortAddrs
 }
 
-// setMgmtPortTestIptables sets up fake IPV4 and IPV6 IPTables helpers with needed chains for management port
-func setMgmtPortTestIptables(configs []managementPortTestConfig) (util.IPTablesHelper, util.IPTablesHelper) {
+// defaultSNATChain is the nat chain used for the default network's
+// management port; secondary networks get their own, suffixed chain (see
+// managementPortGeneric.snatChainName).
+const defaultSNATChain = "OVN-KUBE-SNAT-MGMTPORT"
+
+// setMgmtPortTestIptables sets up fake IPV4 and IPV6 IPTables helpers with needed chains for management port.
+// When tproxy is true, it also seeds the mangle/PREROUTING chain that mp-tproxy hooks into.
+func setMgmtPortTestIptables(configs []managementPortTestConfig, snatChain string, tproxy bool) (util.IPTablesHelper, util.IPTablesHelper) {
 	var err error
 	iptV4, iptV6 := util.SetFakeIPTablesHelpers()
 	for _, cfg := range configs {
-		if cfg.protocol == iptables.ProtocolIPv4 {
-			err = iptV4.NewChain("nat", "POSTROUTING")
-			Expect(err).NotTo(HaveOccurred())
-			err = iptV4.NewChain("nat", "OVN-KUBE-SNAT-MGMTPORT")
-			Expect(err).NotTo(HaveOccurred())
-		} else {
-			err = iptV6.NewChain("nat", "POSTROUTING")
-			Expect(err).NotTo(HaveOccurred())
-			err = iptV6.NewChain("nat", "OVN-KUBE-SNAT-MGMTPORT")
+		ipt := iptV4
+		if cfg.protocol == iptables.ProtocolIPv6 {
+			ipt = iptV6
+		}
+		err = ipt.NewChain("nat", "POSTROUTING")
+		Expect(err).NotTo(HaveOccurred())
+		err = ipt.NewChain("nat", snatChain)
+		Expect(err).NotTo(HaveOccurred())
+		if tproxy {
+			err = ipt.NewChain("mangle", "PREROUTING")
 			Expect(err).NotTo(HaveOccurred())
 		}
 	}
 	return iptV4, iptV6
 }
 
-// checkMgmtPortTestIptables validates Iptables rules for management port
-func checkMgmtPortTestIptables(configs []managementPortTestConfig, mgmtPortName string,
-	fakeIpv4, fakeIpv6 *util.FakeIPTables) {
+// checkMgmtPortTestIptables validates Iptables rules for management port. When tproxy
+// is true, it additionally validates the mangle/OVN-KUBE-TPROXY chain and its jump rule.
+func checkMgmtPortTestIptables(configs []managementPortTestConfig, mgmtPortName, snatChain string,
+	fakeIpv4, fakeIpv6 *util.FakeIPTables, tproxy bool) {
 	var err error
 	for _, cfg := range configs {
+		mangle := util.FakeTable{}
+		if tproxy {
+			mangle["PREROUTING"] = []string{"-j OVN-KUBE-TPROXY"}
+			mangle["OVN-KUBE-TPROXY"] = []string{}
+		}
 		expectedTables := map[string]util.FakeTable{
 			"nat": {
 				"POSTROUTING": []string{
-					"-o " + mgmtPortName + " -j OVN-KUBE-SNAT-MGMTPORT",
+					"-o " + mgmtPortName + " -j " + snatChain,
 				},
-				"OVN-KUBE-SNAT-MGMTPORT": []string{
+				snatChain: []string{
 					"-o " + mgmtPortName + " -j SNAT --to-source " + cfg.expectedManagementPortIP + " -m comment --comment OVN SNAT to Management Port",
 				},
 			},
 			"filter": {},
-			"mangle": {},
+			"mangle": mangle,
 		}
 		if cfg.protocol == iptables.ProtocolIPv4 {
 			err = fakeIpv4.MatchState(expectedTables)
@@ -123,6 +136,25 @@ func checkMgmtPortTestIptables(configs []managementPortTestConfig, mgmtPortName
 	}
 }
 
+// checkMgmtPortTestTProxyPolicyRouting validates the fwmark ip rule and local default
+// route that mp-tproxy installs for a single IP family.
+func checkMgmtPortTestTProxyPolicyRouting(family int) {
+	rules, err := netlink.RuleList(family)
+	Expect(err).NotTo(HaveOccurred())
+	var foundRule bool
+	for _, r := range rules {
+		if r.Mark == 1 && r.Mask == 1 && r.Table == 100 {
+			foundRule = true
+			break
+		}
+	}
+	Expect(foundRule).To(BeTrue(), "did not find expected fwmark ip rule for TPROXY")
+
+	routes, err := netlink.RouteListFiltered(family, &netlink.Route{Table: 100}, netlink.RT_FILTER_TABLE)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(routes).NotTo(BeEmpty(), "did not find expected local default route for TPROXY")
+}
+
 // checkMgmtTestPortIpsAndRoutes checks IPs and Routes of the management port
 func checkMgmtTestPortIpsAndRoutes(configs []managementPortTestConfig, mgmtPortName string,
 	mgtPortAddrs []*netlink.Addr, expectedLRPMAC string) {
@@ -178,6 +210,39 @@ func checkMgmtTestPortIpsAndRoutes(configs []managementPortTestConfig, mgmtPortN
 	}
 }
 
+// checkGatewayConfig validates the GatewayConfig Create() exposes for other node
+// subsystems to consume, independently of the raw netlink state already covered
+// by checkMgmtTestPortIpsAndRoutes.
+func checkGatewayConfig(gwCfg *GatewayConfig, configs []managementPortTestConfig, expectedLRPMAC string) {
+	Expect(gwCfg).NotTo(BeNil())
+	Expect(gwCfg.IPs).To(HaveLen(len(configs)))
+	for _, cfg := range configs {
+		var foundIP bool
+		for _, ip := range gwCfg.IPs {
+			if ip.Equal(ovntest.MustParseIP(cfg.expectedManagementPortIP)) {
+				foundIP = true
+				break
+			}
+		}
+		Expect(foundIP).To(BeTrue(), "GatewayConfig did not contain expected management port IP %s", cfg.expectedManagementPortIP)
+
+		mac, ok := gwCfg.MACs[cfg.family]
+		Expect(ok).To(BeTrue(), "GatewayConfig did not contain a MAC for family %d", cfg.family)
+		Expect(mac.String()).To(Equal(expectedLRPMAC))
+
+		routes, ok := gwCfg.Routes[cfg.family]
+		Expect(ok).To(BeTrue(), "GatewayConfig did not contain routes for family %d", cfg.family)
+		var foundRoute bool
+		for _, r := range routes {
+			if r.Dst.String() == cfg.clusterCIDR {
+				foundRoute = true
+				break
+			}
+		}
+		Expect(foundRoute).To(BeTrue(), "GatewayConfig did not contain a route to %s", cfg.clusterCIDR)
+	}
+}
+
 func testManagementPort(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
 	configs []managementPortTestConfig, expectedLRPMAC string) {
 	const (
@@ -220,7 +285,7 @@ func testManagementPort(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.Net
 		mgtPortAddrs[i] = cfg.GetMgtPortAddr()
 	}
 
-	iptV4, iptV6 := setMgmtPortTestIptables(configs)
+	iptV4, iptV6 := setMgmtPortTestIptables(configs, defaultSNATChain, false)
 
 	existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{
 		Name: nodeName,
@@ -243,6 +308,7 @@ func testManagementPort(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.Net
 		_, err = mgmtPort.Create(nodeAnnotator, waiter)
 		Expect(err).NotTo(HaveOccurred())
 		checkMgmtTestPortIpsAndRoutes(configs, mgtPort, mgtPortAddrs, expectedLRPMAC)
+		checkGatewayConfig(mgmtPort.GatewayConfig(), configs, expectedLRPMAC)
 		return nil
 	})
 	Expect(err).NotTo(HaveOccurred())
@@ -252,7 +318,7 @@ func testManagementPort(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.Net
 	err = waiter.Wait()
 	Expect(err).NotTo(HaveOccurred())
 
-	checkMgmtPortTestIptables(configs, mgtPort, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables))
+	checkMgmtPortTestIptables(configs, mgtPort, defaultSNATChain, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables), false)
 
 	updatedNode, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
 	Expect(err).NotTo(HaveOccurred())
@@ -264,21 +330,35 @@ func testManagementPort(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.Net
 	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
 }
 
-func testManagementPortDPU(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
-	configs []managementPortTestConfig) {
+// testManagementPortVLAN sets up the management port as testManagementPort
+// does and checks its addresses, routes and SNAT chain, plus the VLAN tag
+// ovs-vsctl sets on ovn-k8s-mp0 and the host-side VLAN sub-interface it
+// creates off the configured uplink; the SNAT chain is checked against
+// that sub-interface rather than ovn-k8s-mp0 itself, since that is the
+// device VLAN-backed traffic actually egresses on. Unlike
+// testManagementPort, it does not check GatewayConfig or the MAC annotation.
+func testManagementPortVLAN(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
+	configs []managementPortTestConfig, expectedLRPMAC string, vlanID int) {
 	const (
-		nodeName   string = "node1"
-		mgtPortMAC string = "0a:58:0a:01:01:02"
-		mgtPort    string = types.K8sMgmtIntfName
-		mtu        int    = 1400
+		nodeName      string = "node1"
+		mgtPortMAC    string = "00:00:00:55:66:77"
+		mgtPort       string = types.K8sMgmtIntfName
+		legacyMgtPort string = types.K8sPrefix + nodeName
+		mtu           string = "1400"
 	)
+	vlanIfName := vlanManagementPortIfName(mgtPort, vlanID)
 
-	// OVS cmd setup
+	// generic setup
+	fexec.AddFakeCmdsNoOutputNoError([]string{
+		"ovs-vsctl --timeout=15 -- --if-exists del-port br-int " + legacyMgtPort + " -- --may-exist add-port br-int " + mgtPort + " -- set interface " + mgtPort + " type=internal mtu_request=" + mtu + " external-ids:iface-id=" + legacyMgtPort,
+	})
 	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
-		Cmd:    fmt.Sprintf("ovs-vsctl --timeout=15 -- --may-exist add-port br-int %s -- set interface %s external-ids:iface-id=%s", mgtPort, mgtPort, "k8s-"+nodeName),
-		Output: "",
+		Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " mac_in_use",
+		Output: mgtPortMAC,
+	})
+	fexec.AddFakeCmdsNoOutputNoError([]string{
+		"ovs-vsctl --timeout=15 set interface " + mgtPort + " " + fmt.Sprintf("mac=%s", strings.ReplaceAll(mgtPortMAC, ":", "\\:")),
 	})
-
 	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 		Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " ofport",
 		Output: "1",
@@ -287,16 +367,23 @@ func testManagementPortDPU(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.
 		Cmd:    "ovs-ofctl --no-stats --no-names dump-flows br-int table=65,out_port=1",
 		Output: " table=65, priority=100,reg15=0x2,metadata=0x2 actions=output:1",
 	})
+	fexec.AddFakeCmdsNoOutputNoError([]string{
+		fmt.Sprintf("ovs-vsctl --timeout=15 set port %s tag=%d", mgtPort, vlanID),
+	})
 
 	err := util.SetExec(fexec)
 	Expect(err).NotTo(HaveOccurred())
 
 	nodeSubnetCIDRs := make([]*net.IPNet, len(configs))
+	mgtPortAddrs := make([]*netlink.Addr, len(configs))
 
 	for i, cfg := range configs {
 		nodeSubnetCIDRs[i] = cfg.GetNodeSubnetCIDR()
+		mgtPortAddrs[i] = cfg.GetMgtPortAddr()
 	}
 
+	iptV4, iptV6 := setMgmtPortTestIptables(configs, defaultSNATChain, false)
+
 	existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{
 		Name: nodeName,
 	}}
@@ -317,11 +404,15 @@ func testManagementPortDPU(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.
 		mgmtPort := NewManagementPort(nodeName, nodeSubnetCIDRs)
 		_, err = mgmtPort.Create(nodeAnnotator, waiter)
 		Expect(err).NotTo(HaveOccurred())
-		// make sure interface was renamed and mtu was set
-		l, err := netlink.LinkByName(mgtPort)
+		checkMgmtTestPortIpsAndRoutes(configs, mgtPort, mgtPortAddrs, expectedLRPMAC)
+
+		vlanLink, err := netlink.LinkByName(vlanIfName)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(l.Attrs().MTU).To(Equal(mtu))
-		Expect(l.Attrs().Flags & net.FlagUp).To(Equal(net.FlagUp))
+		Expect(vlanLink.Type()).To(Equal("vlan"))
+		vlan, ok := vlanLink.(*netlink.Vlan)
+		Expect(ok).To(BeTrue(), "expected %s to be a VLAN link", vlanIfName)
+		Expect(vlan.VlanId).To(Equal(vlanID))
+
 		return nil
 	})
 	Expect(err).NotTo(HaveOccurred())
@@ -331,120 +422,477 @@ func testManagementPortDPU(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.
 	err = waiter.Wait()
 	Expect(err).NotTo(HaveOccurred())
 
-	updatedNode, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	// VLAN-backed traffic egresses via the VLAN sub-interface, not ovn-k8s-mp0 itself.
+	checkMgmtPortTestIptables(configs, vlanIfName, defaultSNATChain, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables), false)
+
+	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+}
+
+// secondaryNetInfo is a minimal NetInfo for a secondary OVN network, just
+// enough to drive NewManagementPortForNetwork in tests without pulling in
+// the full NetworkAttachmentDefinition machinery.
+type secondaryNetInfo struct {
+	name           string
+	clusterSubnets []*net.IPNet
+}
+
+func (n secondaryNetInfo) GetNetworkName() string       { return n.name }
+func (n secondaryNetInfo) IsSecondary() bool            { return true }
+func (n secondaryNetInfo) ClusterSubnets() []*net.IPNet { return n.clusterSubnets }
+
+// multiNetworkTestConfig bundles a secondary network's identity together
+// with the mgmt port config and fake MAC the OVS port should come up with,
+// so testManagementPortMultiNetwork can program several networks' ports in
+// one pass.
+type multiNetworkTestConfig struct {
+	netInfo        NetInfo
+	cfg            managementPortTestConfig
+	mgtPortMAC     string
+	expectedLRPMAC string
+}
+
+// testManagementPortMultiNetwork exercises NewManagementPortForNetwork for
+// several secondary networks on the same node, asserting that each gets its
+// own ovn-k8s-mp-<netName> port with a distinct MAC, its own route to its
+// own cluster subnet, its own SNAT chain, and its own entry in the
+// per-network MAC annotation.
+func testManagementPortMultiNetwork(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS, networks []multiNetworkTestConfig) {
+	const (
+		nodeName string = "node1"
+		mtu      string = "1400"
+	)
+
+	for _, n := range networks {
+		mgtPort := types.ManagementPortPrefix + n.netInfo.GetNetworkName()
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 -- --may-exist add-port br-int " + mgtPort + " -- set interface " + mgtPort + " type=internal mtu_request=" + mtu + " external-ids:iface-id=" + mgtPort,
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " mac_in_use",
+			Output: n.mgtPortMAC,
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 set interface " + mgtPort + " " + fmt.Sprintf("mac=%s", strings.ReplaceAll(n.mgtPortMAC, ":", "\\:")),
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " ofport",
+			Output: "1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-ofctl --no-stats --no-names dump-flows br-int table=65,out_port=1",
+			Output: " table=65, priority=100,reg15=0x2,metadata=0x2 actions=output:1",
+		})
+	}
+
+	err := util.SetExec(fexec)
 	Expect(err).NotTo(HaveOccurred())
 
-	macFromAnnotation, err := util.ParseNodeManagementPortMACAddress(updatedNode)
+	_, err = config.InitConfig(ctx, fexec, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: nodeName,
+	}}
+	fakeClient := fake.NewSimpleClientset(&v1.NodeList{
+		Items: []v1.Node{existingNode},
+	})
+	nodeAnnotator := kube.NewNodeAnnotator(&kube.Kube{fakeClient, egressipv1fake.NewSimpleClientset(), &egressfirewallfake.Clientset{}, nil}, existingNode.Name)
+	waiter := newStartupWaiter()
+
+	routeTables := map[string][]*netlink.Route{}
+
+	for _, n := range networks {
+		configs := []managementPortTestConfig{n.cfg}
+		snatChain := snatMgmtPortChain + "-" + n.netInfo.GetNetworkName()
+		mgtPort := types.ManagementPortPrefix + n.netInfo.GetNetworkName()
+
+		iptV4, iptV6 := setMgmtPortTestIptables(configs, snatChain, false)
+
+		nodeSubnetCIDR := n.cfg.GetNodeSubnetCIDR()
+		mgtPortAddr := n.cfg.GetMgtPortAddr()
+
+		err = testNS.Do(func(ns.NetNS) error {
+			defer GinkgoRecover()
+
+			// Stand in for OVS, which creates the internal port, with the MAC
+			// it reports as mac_in_use, when the add-port command runs.
+			ovntest.AddLink(mgtPort)
+			mpLink, err := netlink.LinkByName(mgtPort)
+			Expect(err).NotTo(HaveOccurred())
+			mpMAC, err := net.ParseMAC(n.mgtPortMAC)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(netlink.LinkSetHardwareAddr(mpLink, mpMAC)).To(Succeed())
+
+			mgmtPort := NewManagementPortForNetwork(n.netInfo, nodeName, []*net.IPNet{nodeSubnetCIDR})
+			_, err = mgmtPort.Create(nodeAnnotator, waiter)
+			Expect(err).NotTo(HaveOccurred())
+
+			checkMgmtTestPortIpsAndRoutes(configs, mgtPort, []*netlink.Addr{mgtPortAddr}, n.expectedLRPMAC)
+			checkGatewayConfig(mgmtPort.GatewayConfig(), configs, n.expectedLRPMAC)
+
+			l, err := netlink.LinkByName(mgtPort)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.Attrs().HardwareAddr.String()).To(Equal(n.mgtPortMAC))
+
+			routes, err := netlink.RouteList(l, n.cfg.family)
+			Expect(err).NotTo(HaveOccurred())
+			routeTables[n.netInfo.GetNetworkName()] = routes
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		checkMgmtPortTestIptables(configs, mgtPort, snatChain, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables), false)
+	}
+
+	// Each network's routes must stay scoped to its own cluster subnet: no
+	// route installed for one network should ever show up in another's table.
+	for _, n := range networks {
+		for otherNet, routes := range routeTables {
+			if otherNet == n.netInfo.GetNetworkName() {
+				continue
+			}
+			for _, r := range routes {
+				Expect(r.Dst.String()).NotTo(Equal(n.cfg.clusterCIDR),
+					"route to %s leaked into network %s's route table", n.cfg.clusterCIDR, otherNet)
+			}
+		}
+	}
+
+	err = nodeAnnotator.Run()
+	Expect(err).NotTo(HaveOccurred())
+	err = waiter.Wait()
+	Expect(err).NotTo(HaveOccurred())
+
+	updatedNode, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
 	Expect(err).NotTo(HaveOccurred())
-	Expect(macFromAnnotation.String()).To(Equal(mgtPortMAC))
+
+	macsByNetwork := map[string]net.HardwareAddr{}
+	for _, n := range networks {
+		mac, err := util.ParseNodeManagementPortMACAddressForNetwork(updatedNode, n.netInfo.GetNetworkName())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mac.String()).To(Equal(n.mgtPortMAC))
+		macsByNetwork[n.netInfo.GetNetworkName()] = mac
+	}
+	for net1, mac1 := range macsByNetwork {
+		for net2, mac2 := range macsByNetwork {
+			if net1 == net2 {
+				continue
+			}
+			Expect(mac1.String()).NotTo(Equal(mac2.String()),
+				"networks %s and %s were annotated with the same management port MAC", net1, net2)
+		}
+	}
 
 	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
 }
 
-func testManagementPortDPUHost(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
+// testManagementPortTProxy sets up the management port as testManagementPort does
+// and checks its addresses, routes and SNAT chain, plus validation (and, at the end,
+// teardown) of the mp-tproxy mangle chain and policy routing. Unlike
+// testManagementPort, it does not check GatewayConfig or the MAC annotation.
+func testManagementPortTProxy(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
 	configs []managementPortTestConfig, expectedLRPMAC string) {
 	const (
-		nodeName   string = "node1"
-		mgtPortMAC string = "0a:58:0a:01:01:02"
-		mgtPort    string = types.K8sMgmtIntfName
-		mtu        int    = 1400
+		nodeName      string = "node1"
+		mgtPortMAC    string = "00:00:00:55:66:77"
+		mgtPort       string = types.K8sMgmtIntfName
+		legacyMgtPort string = types.K8sPrefix + nodeName
+		mtu           string = "1400"
 	)
 
+	fexec.AddFakeCmdsNoOutputNoError([]string{
+		"ovs-vsctl --timeout=15 -- --if-exists del-port br-int " + legacyMgtPort + " -- --may-exist add-port br-int " + mgtPort + " -- set interface " + mgtPort + " type=internal mtu_request=" + mtu + " external-ids:iface-id=" + legacyMgtPort,
+	})
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " mac_in_use",
+		Output: mgtPortMAC,
+	})
+	fexec.AddFakeCmdsNoOutputNoError([]string{
+		"ovs-vsctl --timeout=15 set interface " + mgtPort + " " + fmt.Sprintf("mac=%s", strings.ReplaceAll(mgtPortMAC, ":", "\\:")),
+	})
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " ofport",
+		Output: "1",
+	})
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-ofctl --no-stats --no-names dump-flows br-int table=65,out_port=1",
+		Output: " table=65, priority=100,reg15=0x2,metadata=0x2 actions=output:1",
+	})
+
 	err := util.SetExec(fexec)
 	Expect(err).NotTo(HaveOccurred())
 
 	nodeSubnetCIDRs := make([]*net.IPNet, len(configs))
 	mgtPortAddrs := make([]*netlink.Addr, len(configs))
-
 	for i, cfg := range configs {
 		nodeSubnetCIDRs[i] = cfg.GetNodeSubnetCIDR()
 		mgtPortAddrs[i] = cfg.GetMgtPortAddr()
 	}
 
-	iptV4, iptV6 := setMgmtPortTestIptables(configs)
+	iptV4, iptV6 := setMgmtPortTestIptables(configs, defaultSNATChain, true)
+
+	existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+	fakeClient := fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{existingNode}})
 
 	_, err = config.InitConfig(ctx, fexec, nil)
 	Expect(err).NotTo(HaveOccurred())
 
+	nodeAnnotator := kube.NewNodeAnnotator(&kube.Kube{fakeClient, egressipv1fake.NewSimpleClientset(), &egressfirewallfake.Clientset{}, nil}, existingNode.Name)
+	waiter := newStartupWaiter()
+
 	err = testNS.Do(func(ns.NetNS) error {
 		defer GinkgoRecover()
 
 		mgmtPort := NewManagementPort(nodeName, nodeSubnetCIDRs)
-		_, err = mgmtPort.Create(nil, nil)
+		_, err = mgmtPort.Create(nodeAnnotator, waiter)
 		Expect(err).NotTo(HaveOccurred())
 		checkMgmtTestPortIpsAndRoutes(configs, mgtPort, mgtPortAddrs, expectedLRPMAC)
-		// check mgmt port MAC, mtu and link state
-		l, err := netlink.LinkByName(mgtPort)
-		Expect(err).NotTo(HaveOccurred())
-		Expect(l.Attrs().HardwareAddr.String()).To(Equal(mgtPortMAC))
-		Expect(l.Attrs().MTU).To(Equal(mtu))
-		Expect(l.Attrs().Flags & net.FlagUp).To(Equal(net.FlagUp))
+		for _, cfg := range configs {
+			checkMgmtPortTestTProxyPolicyRouting(cfg.family)
+		}
 		return nil
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	checkMgmtPortTestIptables(configs, mgtPort, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables))
+	err = nodeAnnotator.Run()
+	Expect(err).NotTo(HaveOccurred())
+	err = waiter.Wait()
+	Expect(err).NotTo(HaveOccurred())
 
-	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
-}
+	checkMgmtPortTestIptables(configs, mgtPort, defaultSNATChain, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables), true)
 
-var _ = Describe("Management Port Operations", func() {
-	var tmpErr error
-	var app *cli.App
-	var testNS ns.NetNS
-	var fexec *ovntest.FakeExec
+	// disabling mp-tproxy must tear down the chain, jump and policy route for every family
+	err = testNS.Do(func(ns.NetNS) error {
+		defer GinkgoRecover()
+		for _, cfg := range configs {
+			Expect(DisableManagementPortTProxy(cfg.protocol, cfg.family)).To(Succeed())
 
-	tmpDir, tmpErr = ioutil.TempDir("", "clusternodetest_certdir")
-	if tmpErr != nil {
-		GinkgoT().Errorf("failed to create tempdir: %v", tmpErr)
+			rules, err := netlink.RuleList(cfg.family)
+			Expect(err).NotTo(HaveOccurred())
+			for _, r := range rules {
+				Expect(r.Table).NotTo(Equal(tproxyTable), "fwmark ip rule for TPROXY was not torn down")
+			}
+
+			routes, err := netlink.RouteListFiltered(cfg.family, &netlink.Route{Table: tproxyTable}, netlink.RT_FILTER_TABLE)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routes).To(BeEmpty(), "local default route for TPROXY was not torn down")
+		}
+		return nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	for _, cfg := range configs {
+		ipt := iptV4.(*util.FakeIPTables)
+		if cfg.protocol == iptables.ProtocolIPv6 {
+			ipt = iptV6.(*util.FakeIPTables)
+		}
+		Expect(ipt.MatchState(map[string]util.FakeTable{
+			"nat": {
+				"POSTROUTING":    []string{"-o " + mgtPort + " -j " + defaultSNATChain},
+				defaultSNATChain: []string{"-o " + mgtPort + " -j SNAT --to-source " + cfg.expectedManagementPortIP + " -m comment --comment OVN SNAT to Management Port"},
+			},
+			"filter": {},
+			"mangle": {"PREROUTING": []string{}},
+		})).NotTo(HaveOccurred(), "TPROXY chain and jump rule were not torn down")
 	}
 
-	BeforeEach(func() {
-		var err error
-		// Restore global default values before each testcase
-		config.PrepareTestConfig()
+	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+}
 
-		app = cli.NewApp()
-		app.Name = "test"
-		app.Flags = config.Flags
+func testManagementPortDPU(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
+	configs []managementPortTestConfig) {
+	const (
+		nodeName   string = "node1"
+		mgtPortMAC string = "0a:58:0a:01:01:02"
+		mgtPort    string = types.K8sMgmtIntfName
+		mtu        int    = 1400
+	)
 
-		testNS, err = testutils.NewNS()
-		Expect(err).NotTo(HaveOccurred())
-		fexec = ovntest.NewFakeExec()
+	// OVS cmd setup
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    fmt.Sprintf("ovs-vsctl --timeout=15 -- --may-exist add-port br-int %s -- set interface %s external-ids:iface-id=%s", mgtPort, mgtPort, "k8s-"+nodeName),
+		Output: "",
 	})
 
-	AfterEach(func() {
-		Expect(testNS.Close()).To(Succeed())
-		Expect(testutils.UnmountNS(testNS)).To(Succeed())
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " ofport",
+		Output: "1",
+	})
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-ofctl --no-stats --no-names dump-flows br-int table=65,out_port=1",
+		Output: " table=65, priority=100,reg15=0x2,metadata=0x2 actions=output:1",
 	})
 
-	const (
-		v4clusterCIDR string = "10.1.0.0/16"
-		v4nodeSubnet  string = "10.1.1.0/24"
-		v4gwIP        string = "10.1.1.1"
-		v4mgtPortIP   string = "10.1.1.2"
-		v4serviceCIDR string = "172.16.1.0/24"
-		v4lrpMAC      string = "0a:58:0a:01:01:01"
+	err := util.SetExec(fexec)
+	Expect(err).NotTo(HaveOccurred())
 
-		v6clusterCIDR string = "fda6::/48"
-		v6nodeSubnet  string = "fda6:0:0:1::/64"
-		v6gwIP        string = "fda6:0:0:1::1"
-		v6mgtPortIP   string = "fda6:0:0:1::2"
-		v6serviceCIDR string = "fc95::/64"
-		// generated from util.IPAddrToHWAddr(net.ParseIP("fda6:0:0:1::1")).String()
+	nodeSubnetCIDRs := make([]*net.IPNet, len(configs))
+
+	for i, cfg := range configs {
+		nodeSubnetCIDRs[i] = cfg.GetNodeSubnetCIDR()
+	}
+
+	existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: nodeName,
+	}}
+
+	fakeClient := fake.NewSimpleClientset(&v1.NodeList{
+		Items: []v1.Node{existingNode},
+	})
+
+	_, err = config.InitConfig(ctx, fexec, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	nodeAnnotator := kube.NewNodeAnnotator(&kube.Kube{fakeClient, egressipv1fake.NewSimpleClientset(), &egressfirewallfake.Clientset{}, nil}, existingNode.Name)
+	waiter := newStartupWaiter()
+
+	err = testNS.Do(func(ns.NetNS) error {
+		defer GinkgoRecover()
+
+		mgmtPort := NewManagementPort(nodeName, nodeSubnetCIDRs)
+		_, err = mgmtPort.Create(nodeAnnotator, waiter)
+		Expect(err).NotTo(HaveOccurred())
+		// make sure interface was renamed and mtu was set
+		l, err := netlink.LinkByName(mgtPort)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(l.Attrs().MTU).To(Equal(mtu))
+		Expect(l.Attrs().Flags & net.FlagUp).To(Equal(net.FlagUp))
+		return nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	err = nodeAnnotator.Run()
+	Expect(err).NotTo(HaveOccurred())
+	err = waiter.Wait()
+	Expect(err).NotTo(HaveOccurred())
+
+	updatedNode, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+
+	macFromAnnotation, err := util.ParseNodeManagementPortMACAddress(updatedNode)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(macFromAnnotation.String()).To(Equal(mgtPortMAC))
+
+	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+}
+
+func testManagementPortDPUHost(ctx *cli.Context, fexec *ovntest.FakeExec, testNS ns.NetNS,
+	configs []managementPortTestConfig, expectedLRPMAC string) {
+	const (
+		nodeName   string = "node1"
+		mgtPortMAC string = "0a:58:0a:01:01:02"
+		mgtPort    string = types.K8sMgmtIntfName
+		mtu        int    = 1400
+	)
+
+	err := util.SetExec(fexec)
+	Expect(err).NotTo(HaveOccurred())
+
+	nodeSubnetCIDRs := make([]*net.IPNet, len(configs))
+	mgtPortAddrs := make([]*netlink.Addr, len(configs))
+
+	for i, cfg := range configs {
+		nodeSubnetCIDRs[i] = cfg.GetNodeSubnetCIDR()
+		mgtPortAddrs[i] = cfg.GetMgtPortAddr()
+	}
+
+	iptV4, iptV6 := setMgmtPortTestIptables(configs, defaultSNATChain, false)
+
+	_, err = config.InitConfig(ctx, fexec, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = testNS.Do(func(ns.NetNS) error {
+		defer GinkgoRecover()
+
+		mgmtPort := NewManagementPort(nodeName, nodeSubnetCIDRs)
+		_, err = mgmtPort.Create(nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		checkMgmtTestPortIpsAndRoutes(configs, mgtPort, mgtPortAddrs, expectedLRPMAC)
+		// check mgmt port MAC, mtu and link state
+		l, err := netlink.LinkByName(mgtPort)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(l.Attrs().HardwareAddr.String()).To(Equal(mgtPortMAC))
+		Expect(l.Attrs().MTU).To(Equal(mtu))
+		Expect(l.Attrs().Flags & net.FlagUp).To(Equal(net.FlagUp))
+		return nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	checkMgmtPortTestIptables(configs, mgtPort, defaultSNATChain, iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables), false)
+
+	Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+}
+
+var _ = Describe("Management Port Operations", func() {
+	var tmpErr error
+	var app *cli.App
+	var testNS ns.NetNS
+	var fexec *ovntest.FakeExec
+
+	tmpDir, tmpErr = ioutil.TempDir("", "clusternodetest_certdir")
+	if tmpErr != nil {
+		GinkgoT().Errorf("failed to create tempdir: %v", tmpErr)
+	}
+
+	BeforeEach(func() {
+		var err error
+		// Restore global default values before each testcase
+		config.PrepareTestConfig()
+
+		app = cli.NewApp()
+		app.Name = "test"
+		app.Flags = config.Flags
+
+		testNS, err = testutils.NewNS()
+		Expect(err).NotTo(HaveOccurred())
+		fexec = ovntest.NewFakeExec()
+	})
+
+	AfterEach(func() {
+		Expect(testNS.Close()).To(Succeed())
+		Expect(testutils.UnmountNS(testNS)).To(Succeed())
+	})
+
+	const (
+		v4clusterCIDR string = "10.1.0.0/16"
+		v4nodeSubnet  string = "10.1.1.0/24"
+		v4gwIP        string = "10.1.1.1"
+		v4mgtPortIP   string = "10.1.1.2"
+		v4serviceCIDR string = "172.16.1.0/24"
+		v4lrpMAC      string = "0a:58:0a:01:01:01"
+
+		v6clusterCIDR string = "fda6::/48"
+		v6nodeSubnet  string = "fda6:0:0:1::/64"
+		v6gwIP        string = "fda6:0:0:1::1"
+		v6mgtPortIP   string = "fda6:0:0:1::2"
+		v6serviceCIDR string = "fc95::/64"
+		// generated from util.IPAddrToHWAddr(net.ParseIP("fda6:0:0:1::1")).String()
 		v6lrpMAC string = "0a:58:23:5a:40:f1"
 
 		mgmtPortNetdev string = "pf0vf0"
+
+		uplinkNetdev string = "breth0"
+		uplinkV4Addr string = "192.168.1.10/24"
 	)
 
 	Context("Management Port, ovnkube node mode full", func() {
 
 		BeforeEach(func() {
 			var err error
-			// Set up a fake k8sMgmt interface
+			// Set up a fake k8sMgmt interface, plus a fake uplink carrying
+			// a global IPv4 address so the node is seen as v4-capable
+			// rather than IPv6-only.
 			err = testNS.Do(func(ns.NetNS) error {
 				defer GinkgoRecover()
 				ovntest.AddLink(types.K8sMgmtIntfName)
+				ovntest.AddLink(uplinkNetdev)
+				link, err := netlink.LinkByName(uplinkNetdev)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				addr, err := netlink.ParseAddr(uplinkV4Addr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.AddrAdd(link, addr)).To(Succeed())
 				return nil
 			})
 			Expect(err).NotTo(HaveOccurred())
@@ -536,6 +984,690 @@ var _ = Describe("Management Port Operations", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		ovntest.OnSupportedPlatformsIt("rolls back every already-applied family if a later one fails", func() {
+			const (
+				nodeName      string = "node1"
+				mgtPortMAC    string = "00:00:00:55:66:77"
+				mgtPort       string = types.K8sMgmtIntfName
+				legacyMgtPort string = types.K8sPrefix + nodeName
+				mtu           string = "1400"
+			)
+			configs := []managementPortTestConfig{
+				{
+					family:   netlink.FAMILY_V4,
+					protocol: iptables.ProtocolIPv4,
+
+					clusterCIDR: v4clusterCIDR,
+					nodeSubnet:  v4nodeSubnet,
+
+					expectedManagementPortIP: v4mgtPortIP,
+					expectedGatewayIP:        v4gwIP,
+				},
+				{
+					family:   netlink.FAMILY_V6,
+					protocol: iptables.ProtocolIPv6,
+
+					clusterCIDR: v6clusterCIDR,
+					nodeSubnet:  v6nodeSubnet,
+
+					expectedManagementPortIP: v6mgtPortIP,
+					expectedGatewayIP:        v6gwIP,
+				},
+			}
+
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=15 -- --if-exists del-port br-int " + legacyMgtPort + " -- --may-exist add-port br-int " + mgtPort + " -- set interface " + mgtPort + " type=internal mtu_request=" + mtu + " external-ids:iface-id=" + legacyMgtPort,
+			})
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " mac_in_use",
+				Output: mgtPortMAC,
+			})
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=15 set interface " + mgtPort + " " + fmt.Sprintf("mac=%s", strings.ReplaceAll(mgtPortMAC, ":", "\\:")),
+			})
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " ofport",
+				Output: "1",
+			})
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-ofctl --no-stats --no-names dump-flows br-int table=65,out_port=1",
+				Output: " table=65, priority=100,reg15=0x2,metadata=0x2 actions=output:1",
+			})
+
+			err := util.SetExec(fexec)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Both families get their nat chains, so address and SNAT
+			// configuration succeeds for each of them. The mp-tproxy
+			// mangle/PREROUTING chain is only pre-seeded for v4, so v6 fails
+			// at the TProxy step - after its own address and SNAT steps have
+			// already succeeded - forcing Create to unwind both families.
+			iptV4, iptV6 := util.SetFakeIPTablesHelpers()
+			for _, ipt := range []util.IPTablesHelper{iptV4, iptV6} {
+				Expect(ipt.NewChain("nat", "POSTROUTING")).NotTo(HaveOccurred())
+				Expect(ipt.NewChain("nat", defaultSNATChain)).NotTo(HaveOccurred())
+			}
+			Expect(iptV4.NewChain("mangle", "PREROUTING")).NotTo(HaveOccurred())
+
+			nodeSubnetCIDRs := make([]*net.IPNet, len(configs))
+			for i, cfg := range configs {
+				nodeSubnetCIDRs[i] = cfg.GetNodeSubnetCIDR()
+			}
+
+			app.Action = func(ctx *cli.Context) error {
+				existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+				fakeClient := fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{existingNode}})
+
+				_, err := config.InitConfig(ctx, fexec, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				nodeAnnotator := kube.NewNodeAnnotator(&kube.Kube{fakeClient, egressipv1fake.NewSimpleClientset(), &egressfirewallfake.Clientset{}, nil}, existingNode.Name)
+				waiter := newStartupWaiter()
+
+				err = testNS.Do(func(ns.NetNS) error {
+					defer GinkgoRecover()
+
+					mgmtPort := NewManagementPort(nodeName, nodeSubnetCIDRs)
+					_, err := mgmtPort.Create(nodeAnnotator, waiter)
+					Expect(err).To(HaveOccurred(), "expected the v6 mp-tproxy step to fail")
+
+					link, err := netlink.LinkByName(mgtPort)
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, cfg := range configs {
+						addrs, err := netlink.AddrList(link, cfg.family)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(addrs).To(BeEmpty(), "rollback left a management port address for family %d in place", cfg.family)
+
+						routes, err := netlink.RouteListFiltered(cfg.family,
+							&netlink.Route{Dst: ovntest.MustParseIPNet(cfg.clusterCIDR)}, netlink.RT_FILTER_DST)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(routes).To(BeEmpty(), "rollback left the route to %s in place", cfg.clusterCIDR)
+
+						neighs, err := netlink.NeighList(link.Attrs().Index, cfg.family)
+						Expect(err).NotTo(HaveOccurred())
+						gatewayIP := ovntest.MustParseIP(cfg.expectedGatewayIP)
+						for _, neigh := range neighs {
+							Expect(neigh.IP.Equal(gatewayIP)).To(BeFalse(),
+								"rollback left the neighbor entry for %s in place", gatewayIP)
+						}
+
+						// v4 is the only family that ever reached mp-tproxy's
+						// policy routing; rollback must have torn that down too.
+						rules, err := netlink.RuleList(cfg.family)
+						Expect(err).NotTo(HaveOccurred())
+						for _, r := range rules {
+							Expect(r.Table).NotTo(Equal(tproxyTable), "rollback left the fwmark ip rule for TPROXY in place")
+						}
+					}
+
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return nil
+			}
+			err = app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR + "," + v6clusterCIDR,
+				"--enable-mp-tproxy",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			// The SNAT chain rollback deletes, but POSTROUTING (like PREROUTING
+			// below) is a built-in chain rollback only ever clears, never removes.
+			Expect(iptV4.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+				"nat":    {"POSTROUTING": []string{}},
+				"filter": {},
+				"mangle": {"PREROUTING": []string{}},
+			})).NotTo(HaveOccurred(), "rollback left orphaned nat/mangle rules behind for v4")
+			Expect(iptV6.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+				"nat":    {"POSTROUTING": []string{}},
+				"filter": {},
+				"mangle": {},
+			})).NotTo(HaveOccurred(), "rollback left orphaned nat/mangle rules behind for v6")
+		})
+	})
+
+	Context("Management Port, ipv6-only node", func() {
+
+		BeforeEach(func() {
+			var err error
+			// Set up a fake k8sMgmt interface; deliberately do not give any
+			// link an IPv4 address, so the node is seen as IPv6-only.
+			err = testNS.Do(func(ns.NetNS) error {
+				defer GinkgoRecover()
+				ovntest.AddLink(types.K8sMgmtIntfName)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("skips all IPv4 configuration when the node has no IPv4 address", func() {
+			app.Action = func(ctx *cli.Context) error {
+				v6Cfg := managementPortTestConfig{
+					family:   netlink.FAMILY_V6,
+					protocol: iptables.ProtocolIPv6,
+
+					clusterCIDR: v6clusterCIDR,
+					serviceCIDR: v6serviceCIDR,
+					nodeSubnet:  v6nodeSubnet,
+
+					expectedManagementPortIP: v6mgtPortIP,
+					expectedGatewayIP:        v6gwIP,
+				}
+				v4NodeSubnet := ovntest.MustParseIPNet(v4nodeSubnet)
+
+				const (
+					nodeName      string = "node1"
+					mgtPortMAC    string = "00:00:00:55:66:77"
+					mgtPort       string = types.K8sMgmtIntfName
+					legacyMgtPort string = types.K8sPrefix + nodeName
+					mtu           string = "1400"
+				)
+
+				fexec.AddFakeCmdsNoOutputNoError([]string{
+					"ovs-vsctl --timeout=15 -- --if-exists del-port br-int " + legacyMgtPort + " -- --may-exist add-port br-int " + mgtPort + " -- set interface " + mgtPort + " type=internal mtu_request=" + mtu + " external-ids:iface-id=" + legacyMgtPort,
+				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " mac_in_use",
+					Output: mgtPortMAC,
+				})
+				fexec.AddFakeCmdsNoOutputNoError([]string{
+					"ovs-vsctl --timeout=15 set interface " + mgtPort + " " + fmt.Sprintf("mac=%s", strings.ReplaceAll(mgtPortMAC, ":", "\\:")),
+				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ovs-vsctl --timeout=15 --if-exists get interface " + mgtPort + " ofport",
+					Output: "1",
+				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ovs-ofctl --no-stats --no-names dump-flows br-int table=65,out_port=1",
+					Output: " table=65, priority=100,reg15=0x2,metadata=0x2 actions=output:1",
+				})
+
+				err := util.SetExec(fexec)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Only pre-create the v6 nat chains: if Create() attempted
+				// any IPv4 SNAT programming on this IPv6-only node, it would
+				// fail to find the (absent) v4 chain.
+				iptV4, iptV6 := setMgmtPortTestIptables([]managementPortTestConfig{v6Cfg}, defaultSNATChain, false)
+
+				existingNode := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+				fakeClient := fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{existingNode}})
+
+				_, err = config.InitConfig(ctx, fexec, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				nodeAnnotator := kube.NewNodeAnnotator(&kube.Kube{fakeClient, egressipv1fake.NewSimpleClientset(), &egressfirewallfake.Clientset{}, nil}, existingNode.Name)
+				waiter := newStartupWaiter()
+
+				err = testNS.Do(func(ns.NetNS) error {
+					defer GinkgoRecover()
+
+					mgmtPort := NewManagementPort(nodeName, []*net.IPNet{v4NodeSubnet, v6Cfg.GetNodeSubnetCIDR()})
+					_, err = mgmtPort.Create(nodeAnnotator, waiter)
+					Expect(err).NotTo(HaveOccurred())
+
+					checkMgmtTestPortIpsAndRoutes([]managementPortTestConfig{v6Cfg}, mgtPort,
+						[]*netlink.Addr{v6Cfg.GetMgtPortAddr()}, v6lrpMAC)
+
+					mgmtPortLink, err := netlink.LinkByName(mgtPort)
+					Expect(err).NotTo(HaveOccurred())
+
+					v4Addrs, err := netlink.AddrList(mgmtPortLink, netlink.FAMILY_V4)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(v4Addrs).To(BeEmpty(), "no IPv4 address should be programmed on an IPv6-only node")
+
+					v4Routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4,
+						&netlink.Route{Dst: ovntest.MustParseIPNet(v4clusterCIDR)}, netlink.RT_FILTER_DST)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(v4Routes).To(BeEmpty(), "no IPv4 route should be programmed on an IPv6-only node")
+
+					v4Neighs, err := netlink.NeighList(mgmtPortLink.Attrs().Index, netlink.FAMILY_V4)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(v4Neighs).To(BeEmpty(), "no IPv4 neighbor entry should be programmed on an IPv6-only node")
+
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = nodeAnnotator.Run()
+				Expect(err).NotTo(HaveOccurred())
+				err = waiter.Wait()
+				Expect(err).NotTo(HaveOccurred())
+
+				checkMgmtPortTestIptables([]managementPortTestConfig{v6Cfg}, mgtPort, defaultSNATChain,
+					iptV4.(*util.FakeIPTables), iptV6.(*util.FakeIPTables), false)
+				Expect(iptV4.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+					"nat": {}, "filter": {}, "mangle": {},
+				})).NotTo(HaveOccurred())
+
+				Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR + "," + v6clusterCIDR,
+				"--k8s-service-cidr=" + v4serviceCIDR + "," + v6serviceCIDR,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Management Port, mp-tproxy enabled", func() {
+
+		BeforeEach(func() {
+			var err error
+			err = testNS.Do(func(ns.NetNS) error {
+				defer GinkgoRecover()
+				ovntest.AddLink(types.K8sMgmtIntfName)
+				ovntest.AddLink(uplinkNetdev)
+				link, err := netlink.LinkByName(uplinkNetdev)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				addr, err := netlink.ParseAddr(uplinkV4Addr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.AddrAdd(link, addr)).To(Succeed())
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("installs TPROXY rules and policy routing for IPv4 clusters, and tears them down on disable", func() {
+			app.Action = func(ctx *cli.Context) error {
+				testManagementPortTProxy(ctx, fexec, testNS,
+					[]managementPortTestConfig{
+						{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: v4clusterCIDR,
+							nodeSubnet:  v4nodeSubnet,
+
+							expectedManagementPortIP: v4mgtPortIP,
+							expectedGatewayIP:        v4gwIP,
+						},
+					}, v4lrpMAC)
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR,
+				"--enable-mp-tproxy",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("installs TPROXY rules and policy routing for IPv6 clusters, and tears them down on disable", func() {
+			app.Action = func(ctx *cli.Context) error {
+				testManagementPortTProxy(ctx, fexec, testNS,
+					[]managementPortTestConfig{
+						{
+							family:   netlink.FAMILY_V6,
+							protocol: iptables.ProtocolIPv6,
+
+							clusterCIDR: v6clusterCIDR,
+							serviceCIDR: v6serviceCIDR,
+							nodeSubnet:  v6nodeSubnet,
+
+							expectedManagementPortIP: v6mgtPortIP,
+							expectedGatewayIP:        v6gwIP,
+						},
+					}, v6lrpMAC)
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v6clusterCIDR,
+				"--k8s-service-cidr=" + v6serviceCIDR,
+				"--enable-mp-tproxy",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("installs TPROXY rules and policy routing for dual-stack clusters, and tears them down on disable", func() {
+			app.Action = func(ctx *cli.Context) error {
+				testManagementPortTProxy(ctx, fexec, testNS,
+					[]managementPortTestConfig{
+						{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: v4clusterCIDR,
+							serviceCIDR: v4serviceCIDR,
+							nodeSubnet:  v4nodeSubnet,
+
+							expectedManagementPortIP: v4mgtPortIP,
+							expectedGatewayIP:        v4gwIP,
+						},
+						{
+							family:   netlink.FAMILY_V6,
+							protocol: iptables.ProtocolIPv6,
+
+							clusterCIDR: v6clusterCIDR,
+							serviceCIDR: v6serviceCIDR,
+							nodeSubnet:  v6nodeSubnet,
+
+							expectedManagementPortIP: v6mgtPortIP,
+							expectedGatewayIP:        v6gwIP,
+						},
+					}, v4lrpMAC)
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR + "," + v6clusterCIDR,
+				"--k8s-service-cidr=" + v4serviceCIDR + "," + v6serviceCIDR,
+				"--enable-mp-tproxy",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("adds and removes a per-service TPROXY redirect rule", func() {
+			const (
+				svcIPStr   string = "172.16.1.5"
+				svcPort    int32  = 80
+				listenPort int32  = 12345
+			)
+
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				iptV4, _ := util.SetFakeIPTablesHelpers()
+				Expect(iptV4.NewChain("mangle", tproxyChain)).NotTo(HaveOccurred())
+
+				svcIP := ovntest.MustParseIP(svcIPStr)
+				Expect(AddLocalServiceTProxyRule(iptables.ProtocolIPv4, "tcp", svcIP, svcPort, listenPort)).To(Succeed())
+
+				expectedRule := fmt.Sprintf("-p tcp -d %s --dport %d -j TPROXY --on-port %d --tproxy-mark %s",
+					svcIPStr, svcPort, listenPort, tproxyMark)
+				Expect(iptV4.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+					"nat":    {},
+					"filter": {},
+					"mangle": {tproxyChain: []string{expectedRule}},
+				})).NotTo(HaveOccurred(), "AddLocalServiceTProxyRule did not install the expected rule")
+
+				Expect(DeleteLocalServiceTProxyRule(iptables.ProtocolIPv4, "tcp", svcIP, svcPort, listenPort)).To(Succeed())
+				Expect(iptV4.(*util.FakeIPTables).MatchState(map[string]util.FakeTable{
+					"nat":    {},
+					"filter": {},
+					"mangle": {tproxyChain: []string{}},
+				})).NotTo(HaveOccurred(), "DeleteLocalServiceTProxyRule did not remove the rule")
+
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR,
+				"--enable-mp-tproxy",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Management Port, vlan-backed", func() {
+		const vlanID int = 100
+
+		BeforeEach(func() {
+			var err error
+			// Set up a fake k8sMgmt interface plus a fake uplink for the
+			// VLAN sub-interface to attach to.
+			err = testNS.Do(func(ns.NetNS) error {
+				defer GinkgoRecover()
+				ovntest.AddLink(types.K8sMgmtIntfName)
+				ovntest.AddLink(uplinkNetdev)
+				link, err := netlink.LinkByName(uplinkNetdev)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				addr, err := netlink.ParseAddr(uplinkV4Addr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.AddrAdd(link, addr)).To(Succeed())
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("tags ovn-k8s-mp0 and creates a VLAN sub-interface for IPv4 clusters", func() {
+			app.Action = func(ctx *cli.Context) error {
+				testManagementPortVLAN(ctx, fexec, testNS,
+					[]managementPortTestConfig{
+						{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: v4clusterCIDR,
+							nodeSubnet:  v4nodeSubnet,
+
+							expectedManagementPortIP: v4mgtPortIP,
+							expectedGatewayIP:        v4gwIP,
+						},
+					}, v4lrpMAC, vlanID)
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR,
+				"--mgmt-port-network-type=vlan",
+				fmt.Sprintf("--mgmt-port-vlan-id=%d", vlanID),
+				"--gateway-interface=" + uplinkNetdev,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("tags ovn-k8s-mp0 and creates a VLAN sub-interface for dual-stack clusters", func() {
+			app.Action = func(ctx *cli.Context) error {
+				testManagementPortVLAN(ctx, fexec, testNS,
+					[]managementPortTestConfig{
+						{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: v4clusterCIDR,
+							serviceCIDR: v4serviceCIDR,
+							nodeSubnet:  v4nodeSubnet,
+
+							expectedManagementPortIP: v4mgtPortIP,
+							expectedGatewayIP:        v4gwIP,
+						},
+						{
+							family:   netlink.FAMILY_V6,
+							protocol: iptables.ProtocolIPv6,
+
+							clusterCIDR: v6clusterCIDR,
+							serviceCIDR: v6serviceCIDR,
+							nodeSubnet:  v6nodeSubnet,
+
+							expectedManagementPortIP: v6mgtPortIP,
+							expectedGatewayIP:        v6gwIP,
+						},
+					}, v4lrpMAC, vlanID)
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR + "," + v6clusterCIDR,
+				"--k8s-service-cidr=" + v4serviceCIDR + "," + v6serviceCIDR,
+				"--mgmt-port-network-type=vlan",
+				fmt.Sprintf("--mgmt-port-vlan-id=%d", vlanID),
+				"--gateway-interface=" + uplinkNetdev,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("does not tag a secondary network's management port when the default network is VLAN-backed", func() {
+			const (
+				netName        string = "red"
+				netClusterCIDR string = "10.2.0.0/16"
+				netNodeSubnet  string = "10.2.1.0/24"
+				netGwIP        string = "10.2.1.1"
+				netMgtPortIP   string = "10.2.1.2"
+				// generated from util.IPAddrToHWAddr(net.ParseIP("10.2.1.1")).String()
+				netLRPMAC     string = "0a:58:0a:02:01:01"
+				netMgtPortMAC string = "00:00:00:55:66:79"
+				netMgtPort    string = types.ManagementPortPrefix + netName
+			)
+
+			app.Action = func(ctx *cli.Context) error {
+				// The default network's VLAN-backed port is created first,
+				// exactly as in the single-network VLAN tests above.
+				testManagementPortVLAN(ctx, fexec, testNS,
+					[]managementPortTestConfig{
+						{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: v4clusterCIDR,
+							nodeSubnet:  v4nodeSubnet,
+
+							expectedManagementPortIP: v4mgtPortIP,
+							expectedGatewayIP:        v4gwIP,
+						},
+					}, v4lrpMAC, vlanID)
+
+				// A secondary network's port must come up on its own
+				// ovn-k8s-mp-red interface without ever attempting to tag it
+				// or create a second VLAN sub-interface for the same
+				// (uplink, VLAN ID) pair the default network already claimed.
+				netCfg := managementPortTestConfig{
+					family:   netlink.FAMILY_V4,
+					protocol: iptables.ProtocolIPv4,
+
+					clusterCIDR: netClusterCIDR,
+					nodeSubnet:  netNodeSubnet,
+
+					expectedManagementPortIP: netMgtPortIP,
+					expectedGatewayIP:        netGwIP,
+				}
+				testManagementPortMultiNetwork(ctx, fexec, testNS, []multiNetworkTestConfig{
+					{
+						netInfo: secondaryNetInfo{
+							name:           netName,
+							clusterSubnets: []*net.IPNet{ovntest.MustParseIPNet(netClusterCIDR)},
+						},
+						cfg:            netCfg,
+						mgtPortMAC:     netMgtPortMAC,
+						expectedLRPMAC: netLRPMAC,
+					},
+				})
+
+				err := testNS.Do(func(ns.NetNS) error {
+					defer GinkgoRecover()
+					_, err := netlink.LinkByName(vlanManagementPortIfName(netMgtPort, vlanID))
+					Expect(err).To(HaveOccurred(), "secondary network must not create its own VLAN sub-interface")
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				return nil
+			}
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=" + v4clusterCIDR,
+				"--mgmt-port-network-type=vlan",
+				fmt.Sprintf("--mgmt-port-vlan-id=%d", vlanID),
+				"--gateway-interface=" + uplinkNetdev,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Management Port, multi-network", func() {
+		const (
+			net1Name        string = "red"
+			net1ClusterCIDR string = "10.2.0.0/16"
+			net1nodeSubnet  string = "10.2.1.0/24"
+			net1gwIP        string = "10.2.1.1"
+			net1mgtPortIP   string = "10.2.1.2"
+			// generated from util.IPAddrToHWAddr(net.ParseIP("10.2.1.1")).String()
+			net1lrpMAC     string = "0a:58:0a:02:01:01"
+			net1mgtPortMAC string = "00:00:00:55:66:78"
+
+			net2Name        string = "blue"
+			net2ClusterCIDR string = "10.3.0.0/16"
+			net2nodeSubnet  string = "10.3.1.0/24"
+			net2gwIP        string = "10.3.1.1"
+			net2mgtPortIP   string = "10.3.1.2"
+			// generated from util.IPAddrToHWAddr(net.ParseIP("10.3.1.1")).String()
+			net2lrpMAC     string = "0a:58:0a:03:01:01"
+			net2mgtPortMAC string = "00:00:00:55:66:79"
+		)
+
+		BeforeEach(func() {
+			var err error
+			// Unlike the default network, secondary management ports have no
+			// legacy name to migrate away from, so there is no pre-existing
+			// link to seed here; testManagementPortMultiNetwork creates each
+			// ovn-k8s-mp-<netName> link itself, standing in for the OVS
+			// add-port command, which the fake exec only records. A fake
+			// uplink carrying a global IPv4 address is still needed so the
+			// node is seen as v4-capable rather than IPv6-only.
+			err = testNS.Do(func(ns.NetNS) error {
+				defer GinkgoRecover()
+				ovntest.AddLink(uplinkNetdev)
+				link, err := netlink.LinkByName(uplinkNetdev)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				addr, err := netlink.ParseAddr(uplinkV4Addr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.AddrAdd(link, addr)).To(Succeed())
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		ovntest.OnSupportedPlatformsIt("creates independent ports, routes, MACs and SNAT chains for two secondary networks", func() {
+			app.Action = func(ctx *cli.Context) error {
+				testManagementPortMultiNetwork(ctx, fexec, testNS, []multiNetworkTestConfig{
+					{
+						netInfo: secondaryNetInfo{
+							name:           net1Name,
+							clusterSubnets: []*net.IPNet{ovntest.MustParseIPNet(net1ClusterCIDR)},
+						},
+						cfg: managementPortTestConfig{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: net1ClusterCIDR,
+							nodeSubnet:  net1nodeSubnet,
+
+							expectedManagementPortIP: net1mgtPortIP,
+							expectedGatewayIP:        net1gwIP,
+						},
+						mgtPortMAC:     net1mgtPortMAC,
+						expectedLRPMAC: net1lrpMAC,
+					},
+					{
+						netInfo: secondaryNetInfo{
+							name:           net2Name,
+							clusterSubnets: []*net.IPNet{ovntest.MustParseIPNet(net2ClusterCIDR)},
+						},
+						cfg: managementPortTestConfig{
+							family:   netlink.FAMILY_V4,
+							protocol: iptables.ProtocolIPv4,
+
+							clusterCIDR: net2ClusterCIDR,
+							nodeSubnet:  net2nodeSubnet,
+
+							expectedManagementPortIP: net2mgtPortIP,
+							expectedGatewayIP:        net2gwIP,
+						},
+						mgtPortMAC:     net2mgtPortMAC,
+						expectedLRPMAC: net2lrpMAC,
+					},
+				})
+				return nil
+			}
+			// The default network's --cluster-subnets deliberately contains
+			// neither secondary network's CIDR: each one's route must come
+			// from its own NetInfo.ClusterSubnets, not from global config.
+			err := app.Run([]string{
+				app.Name,
+				"--cluster-subnets=10.128.0.0/14",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Context("Management Port, ovnkube node mode dpu", func() {
@@ -583,10 +1715,19 @@ var _ = Describe("Management Port Operations", func() {
 	Context("Management Port, ovnkube node mode dpu-host", func() {
 		BeforeEach(func() {
 			var err error
-			// Set up a fake k8sMgmt interface
+			// Set up a fake k8sMgmt interface, plus a fake uplink carrying
+			// a global IPv4 address so the node is seen as v4-capable
+			// rather than IPv6-only.
 			err = testNS.Do(func(ns.NetNS) error {
 				defer GinkgoRecover()
 				ovntest.AddLink(mgmtPortNetdev)
+				ovntest.AddLink(uplinkNetdev)
+				link, err := netlink.LinkByName(uplinkNetdev)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.LinkSetUp(link)).To(Succeed())
+				addr, err := netlink.ParseAddr(uplinkV4Addr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(netlink.AddrAdd(link, addr)).To(Succeed())
 				return nil
 			})
 			Expect(err).NotTo(HaveOccurred())